@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions and condition Reasons for the VultrCluster object.
+
+const (
+	// LoadBalancerReadyCondition reports on whether the API server load balancer is ready.
+	LoadBalancerReadyCondition clusterv1.ConditionType = "LoadBalancerReady"
+
+	// LoadBalancerProvisionFailedReason used when the Vultr API rejected the load balancer.
+	LoadBalancerProvisionFailedReason = "LoadBalancerProvisionFailed"
+	// LoadBalancerProvisioningReason used while the load balancer waits for its IP address.
+	LoadBalancerProvisioningReason = "LoadBalancerProvisioning"
+	// LoadBalancerNotFoundReason used when the load balancer could not be found.
+	LoadBalancerNotFoundReason = "LoadBalancerNotFound"
+)
+
+// Conditions and condition Reasons shared by the VultrCluster and VultrMachine objects.
+
+const (
+	// VPCReadyCondition reports on whether the VPC referenced in the spec exists.
+	// It is only set when a VPC is referenced.
+	VPCReadyCondition clusterv1.ConditionType = "VPCReady"
+
+	// VPCNotFoundReason used when the referenced VPC does not exist.
+	VPCNotFoundReason = "VPCNotFound"
+	// VPCLookupFailedReason used when the VPC could not be retrieved from the Vultr API.
+	VPCLookupFailedReason = "VPCLookupFailed"
+)
+
+// Conditions and condition Reasons for the VultrMachine object.
+
+const (
+	// FirewallReadyCondition reports on whether the firewall group referenced in the spec exists.
+	// It is only set when a firewall group is referenced.
+	FirewallReadyCondition clusterv1.ConditionType = "FirewallReady"
+
+	// FirewallGroupNotFoundReason used when the referenced firewall group does not exist.
+	FirewallGroupNotFoundReason = "FirewallGroupNotFound"
+	// FirewallGroupLookupFailedReason used when the firewall group could not be retrieved from the Vultr API.
+	FirewallGroupLookupFailedReason = "FirewallGroupLookupFailed"
+)
+
+const (
+	// PreflightChecksPassedCondition reports on whether the plan, region, snapshot and VPC of the
+	// VultrMachine spec were validated against the Vultr API. It is only set before the instance is created.
+	PreflightChecksPassedCondition clusterv1.ConditionType = "PreflightChecksPassed"
+
+	// RegionNotFoundReason used when the region of the spec does not exist.
+	RegionNotFoundReason = "RegionNotFound"
+	// PlanNotAvailableReason used when the plan of the spec is not offered in the region, or does not match the machine type.
+	PlanNotAvailableReason = "PlanNotAvailable"
+	// SnapshotNotFoundReason used when the snapshot of the spec does not exist.
+	SnapshotNotFoundReason = "SnapshotNotFound"
+	// SnapshotNotReadyReason used while the snapshot of the spec is not complete yet.
+	SnapshotNotReadyReason = "SnapshotNotReady"
+	// VPCRegionMismatchReason used when the VPC of the spec is in another region than the machine.
+	VPCRegionMismatchReason = "VPCRegionMismatch"
+	// PreflightLookupFailedReason used when the preflight checks could not query the Vultr API.
+	PreflightLookupFailedReason = "PreflightLookupFailed"
+)
+
+const (
+	// BootstrapDataAvailableCondition reports on whether the bootstrap data secret is available.
+	BootstrapDataAvailableCondition clusterv1.ConditionType = "BootstrapDataAvailable"
+
+	// WaitingForBootstrapDataReason used when the bootstrap data secret is not yet available.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+)
+
+const (
+	// InstanceProvisionedCondition reports on whether the Vultr instance has been created.
+	InstanceProvisionedCondition clusterv1.ConditionType = "InstanceProvisioned"
+
+	// InstanceProvisionFailedReason used when the Vultr API rejected the instance.
+	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// InstanceTerminatedReason used when the instance subscription has been closed.
+	InstanceTerminatedReason = "InstanceTerminated"
+	// InstanceDeletedReason used when the instance was deleted outside of Cluster API.
+	InstanceDeletedReason = "InstanceDeleted"
+	// WaitingForClusterInfrastructureReason used when the cluster infrastructure is not ready yet.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+)
+
+const (
+	// InstanceRunningCondition reports on whether the Vultr instance is active and running.
+	InstanceRunningCondition clusterv1.ConditionType = "InstanceRunning"
+
+	// InstancePendingReason used while the instance subscription is pending.
+	InstancePendingReason = "InstancePending"
+	// InstanceNotActiveReason used when the instance subscription is neither pending nor active.
+	InstanceNotActiveReason = "InstanceNotActive"
+	// InstanceStoppedReason used when the instance is powered off.
+	InstanceStoppedReason = "InstanceStopped"
+	// InstanceStartingReason used while a stopped instance is started again.
+	InstanceStartingReason = "InstanceStarting"
+	// InstanceLockedReason used while the instance is locked, e.g. by a pending Vultr operation.
+	InstanceLockedReason = "InstanceLocked"
+)
+
+const (
+	// LoadBalancerAttachedCondition reports on whether a control plane instance is attached
+	// to the API server load balancer. It is only set for control plane machines.
+	LoadBalancerAttachedCondition clusterv1.ConditionType = "LoadBalancerAttached"
+
+	// LoadBalancerAttachFailedReason used when the instance could not be attached to the load balancer.
+	LoadBalancerAttachFailedReason = "LoadBalancerAttachFailed"
+	// LoadBalancerAttachPendingReason used while the load balancer is not active yet.
+	LoadBalancerAttachPendingReason = "LoadBalancerAttachPending"
+)
+
+const (
+	// PlanUpToDateCondition reports on whether the instance runs the plan of the VultrMachine spec.
+	// It is only set once the plan of an existing instance was changed.
+	PlanUpToDateCondition clusterv1.ConditionType = "PlanUpToDate"
+
+	// PlanUpgradingReason used while the instance is resized to a new plan.
+	PlanUpgradingReason = "PlanUpgrading"
+	// PlanUpgradeDisabledReason used when the plan changed but in-place plan upgrades are disabled.
+	PlanUpgradeDisabledReason = "PlanUpgradeDisabled"
+	// PlanUpgradeRejectedReason used when the new plan is not an available upgrade of the instance, e.g. a downgrade.
+	PlanUpgradeRejectedReason = "PlanUpgradeRejected"
+	// PlanUpgradeFailedReason used when the Vultr API failed to upgrade the instance.
+	PlanUpgradeFailedReason = "PlanUpgradeFailed"
+)
+
+const (
+	// DataVolumesReadyCondition reports on whether the data volumes of the VultrMachine spec are created
+	// and attached to the instance. It is only set for machines with data volumes.
+	DataVolumesReadyCondition clusterv1.ConditionType = "DataVolumesReady"
+
+	// DataVolumeCreateFailedReason used when a block storage could not be created.
+	DataVolumeCreateFailedReason = "DataVolumeCreateFailed"
+	// DataVolumeAttachingReason used while the block storages wait to be attached to the instance.
+	DataVolumeAttachingReason = "DataVolumeAttaching"
+	// DataVolumeAttachFailedReason used when a block storage could not be attached to the instance.
+	DataVolumeAttachFailedReason = "DataVolumeAttachFailed"
+)
@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 )
 
 // Tags defines a slice of tags.
@@ -51,6 +53,12 @@ func ClusterNameUIDRoleTag(clusterName, clusterUID, role string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", NameVultrProviderPrefix, clusterName, clusterUID, role)
 }
 
+// IsProviderTag reports whether tag is one of the tags the provider sets on
+// every resource, see BuildTags.
+func IsProviderTag(tag string) bool {
+	return strings.HasPrefix(tag, NameVultrProviderPrefix) || strings.HasPrefix(tag, NameTagFromName(""))
+}
+
 // NameTagFromName returns Vultr safe name tag from name.
 func NameTagFromName(name string) string {
 	return fmt.Sprintf("name:%s", name)
@@ -82,3 +90,14 @@ func BuildTags(params BuildTagParams) Tags {
 	tags = append(tags, params.Additional...)
 	return tags
 }
+
+// Equal reports whether t and other contain the same tags, in any order.
+func (t Tags) Equal(other []string) bool {
+	return slices.Equal(sortedTags(t), sortedTags(other))
+}
+
+func sortedTags(tags []string) []string {
+	sorted := slices.Clone(tags)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
@@ -46,6 +46,29 @@ var (
 	ServerStateError       = ServerState("error")
 )
 
+// StartPolicy defines whether instances which were stopped outside of
+// Cluster API are started again.
+// +kubebuilder:validation:Enum=Never;Always
+type StartPolicy string
+
+const (
+	// StartPolicyNever leaves stopped instances stopped and the machine not ready.
+	StartPolicyNever = StartPolicy("Never")
+	// StartPolicyAlways starts stopped instances again.
+	StartPolicyAlways = StartPolicy("Always")
+)
+
+// MachineType is the kind of Vultr server backing a VultrMachine.
+// +kubebuilder:validation:Enum=Instance;BareMetal
+type MachineType string
+
+const (
+	// MachineTypeInstance is a cloud compute instance.
+	MachineTypeInstance = MachineType("Instance")
+	// MachineTypeBareMetal is a bare metal server.
+	MachineTypeBareMetal = MachineType("BareMetal")
+)
+
 // VultrResourceReference is a reference to a Vultr resource.
 type VultrResourceReference struct {
 	// ID of Vultr resource
@@ -139,6 +162,7 @@ type LBFirewallRule struct {
 // VultrMachineTemplateResource describes the data needed to create a VultrMachine from a template.
 type VultrMachineTemplateResource struct {
 	// Spec is the specification of the desired behavior of the machine.
+	// +kubebuilder:validation:XValidation:rule="!has(self.providerID)",message="providerID is set by the controller and must not be set in templates"
 	Spec VultrMachineSpec `json:"spec"`
 }
 
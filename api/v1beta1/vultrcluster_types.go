@@ -47,6 +47,15 @@ type VultrClusterSpec struct {
 	// VPCID is the Vultr VPC ID used for the cluster's load balancer.
 	// +optional
 	VPCID string `json:"vpc_id,omitempty"`
+
+	// AdditionalTags are added to the Vultr instances and bare metal servers
+	// of all machines of the cluster, besides the tags of the provider.
+	// Changes are applied to existing servers.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self.all(t, !t.startsWith('sigs-k8s-io:capvultr') && !t.startsWith('name:'))",message="tags starting with sigs-k8s-io:capvultr or name: are reserved for the provider"
+	AdditionalTags []string `json:"additionalTags,omitempty"`
 }
 
 // VultrClusterStatus defines the observed state of VultrCluster
@@ -100,9 +109,37 @@ type VultrClusterStatus struct {
 	// Network encapsulates all things related to the Vultr network.
 	// +optional
 	Network VultrNetworkResource `json:"network,omitempty"`
+
+	// Initialization provides observations of the VultrCluster initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Cluster provisioning.
+	// +optional
+	Initialization *VultrClusterInitializationStatus `json:"initialization,omitempty"`
+
+	// V1Beta2 groups all the fields that will be added or modified in VultrCluster's status with the V1Beta2 version.
+	// +optional
+	V1Beta2 *VultrClusterV1Beta2Status `json:"v1beta2,omitempty"`
+}
+
+// VultrClusterInitializationStatus provides observations of the VultrCluster initialization process.
+type VultrClusterInitializationStatus struct {
+	// Provisioned is true when the infrastructure provider reports that the cluster infrastructure is fully provisioned.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// VultrClusterV1Beta2Status groups all the fields that will be added or modified in VultrClusterStatus with the V1Beta2 version.
+// See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
+type VultrClusterV1Beta2Status struct {
+	// Conditions represents the observations of a VultrCluster's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=vultrclusters,scope=Namespaced,categories=cluster-api
 //+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this VultrCluster belongs"
@@ -126,6 +163,22 @@ func (r *VultrCluster) SetConditions(conditions clusterv1.Conditions) {
 	r.Status.Conditions = conditions
 }
 
+// GetV1Beta2Conditions returns the set of conditions for this object.
+func (r *VultrCluster) GetV1Beta2Conditions() []metav1.Condition {
+	if r.Status.V1Beta2 == nil {
+		return nil
+	}
+	return r.Status.V1Beta2.Conditions
+}
+
+// SetV1Beta2Conditions sets conditions for an API object.
+func (r *VultrCluster) SetV1Beta2Conditions(conditions []metav1.Condition) {
+	if r.Status.V1Beta2 == nil {
+		r.Status.V1Beta2 = &VultrClusterV1Beta2Status{}
+	}
+	r.Status.V1Beta2.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // VultrClusterList contains a list of VultrCluster
@@ -30,12 +30,17 @@ type VultrClusterTemplateSpec struct {
 
 // VultrClusterTemplateResource contains spec for VultrClusterSpec.
 type VultrClusterTemplateResource struct {
+	// Spec is the specification of the VultrClusters created from the template.
+	// The load balancer and control plane endpoint are created per cluster by
+	// the controller, so they must be left empty.
+	// +kubebuilder:validation:XValidation:rule="!has(self.controlPlaneEndpoint) || (self.controlPlaneEndpoint.host == '' && self.controlPlaneEndpoint.port == 0)",message="controlPlaneEndpoint is set by the controller and must be empty in templates"
+	// +kubebuilder:validation:XValidation:rule="!has(self.network) || !has(self.network.apiServerLoadbalancers) || !(has(self.network.apiServerLoadbalancers.id) || has(self.network.apiServerLoadbalancers.date_created) || has(self.network.apiServerLoadbalancers.status) || has(self.network.apiServerLoadbalancers.ipv4) || has(self.network.apiServerLoadbalancers.ipv6) || has(self.network.apiServerLoadbalancers.instances))",message="the load balancer id, status, addresses and instances are set by the controller and must not be set in templates"
 	Spec VultrClusterSpec `json:"spec"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:path=vultrclustertemplates,scope=Namespaced,categories=cluster-api,shortName=vct
-//+kubebuilder:subresource:status
 
 // VultrClusterTemplate is the Schema for the vultrclustertemplates API
 type VultrClusterTemplate struct {
@@ -27,12 +27,21 @@ const (
 	// MachineFinalizer allows ReconcileVultrMachine to clean up Vultr resources associated with VultrMachine before
 	// removing it from the apiserver.
 	MachineFinalizer = "vultrmachine.infrastructure.cluster.x-k8s.io"
+
+	// ActionAnnotation requests a MachineAction on the instance of a
+	// VultrMachine. It is removed once the action was handled.
+	ActionAnnotation = "infrastructure.cluster.x-k8s.io/vultr-action"
+	// ForceActionAnnotation set to "true" allows actions on control plane
+	// machines.
+	ForceActionAnnotation = "infrastructure.cluster.x-k8s.io/vultr-action-force"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // VultrMachineSpec defines the desired state of VultrMachine
+// +kubebuilder:validation:XValidation:rule="!has(self.machineType) || self.machineType != 'BareMetal' || (!has(self.dataVolumes) && !has(self.firewall_group_id))",message="bare metal machines support neither data volumes nor firewall groups"
+// +kubebuilder:validation:XValidation:rule="(has(self.machineType) ? self.machineType : 'Instance') == (has(oldSelf.machineType) ? oldSelf.machineType : 'Instance')",message="machineType is immutable"
 type VultrMachineSpec struct {
 	// Foo is an example field of VultrMachine. Edit vultrmachine_types.go to remove/update
 	// ProviderID is the unique identifier as specified by the cloud provider.
@@ -69,6 +78,48 @@ type VultrMachineSpec struct {
 	// future release
 	// +optional
 	VPC2ID string `json:"vpc2_id,omitempty"`
+
+	// StartPolicy defines whether the instance is started again when it was
+	// stopped outside of Cluster API, e.g. in the Vultr console. Defaults to
+	// Never.
+	// +optional
+	StartPolicy StartPolicy `json:"startPolicy,omitempty"`
+
+	// InPlacePlanUpgrade resizes the existing instance when PlanID changes,
+	// instead of leaving it on its current plan. Only upgrades to a larger
+	// plan are possible.
+	// +optional
+	InPlacePlanUpgrade bool `json:"inPlacePlanUpgrade,omitempty"`
+
+	// EnableIPv6 assigns a public IPv6 address to the instance. Defaults to
+	// true, has no effect when VPCOnly is set.
+	// +optional
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+
+	// DataVolumes are Vultr Block Storage volumes created in the region of the
+	// machine and attached to its instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=label
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+
+	// MachineType is the kind of Vultr server backing the machine. BareMetal
+	// machines use a bare metal plan, e.g. vbm-4c-32gb, and can only be
+	// worker machines. Defaults to Instance. Instances and bare metal servers
+	// both have provider IDs of the form vultr://<id>, as set on their Nodes
+	// by the Vultr cloud controller manager, so the machine type is what
+	// tells them apart.
+	// +optional
+	MachineType MachineType `json:"machineType,omitempty"`
+
+	// AdditionalTags are added to the instance or bare metal server of the
+	// machine, besides the additional tags of the VultrCluster and the tags
+	// of the provider. Changes are applied to the existing server.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self.all(t, !t.startsWith('sigs-k8s-io:capvultr') && !t.startsWith('name:'))",message="tags starting with sigs-k8s-io:capvultr or name: are reserved for the provider"
+	AdditionalTags []string `json:"additionalTags,omitempty"`
 }
 
 // VultrMachineStatus defines the observed state of VultrMachine
@@ -134,6 +185,189 @@ type VultrMachineStatus struct {
 	// Conditions defines current service state of the VultrCluster.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// Initialization provides observations of the VultrMachine initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Machine provisioning.
+	// +optional
+	Initialization *VultrMachineInitializationStatus `json:"initialization,omitempty"`
+
+	// V1Beta2 groups all the fields that will be added or modified in VultrMachine's status with the V1Beta2 version.
+	// +optional
+	V1Beta2 *VultrMachineV1Beta2Status `json:"v1beta2,omitempty"`
+
+	// LastAction is the last action requested through the action annotation.
+	// +optional
+	LastAction *VultrMachineActionStatus `json:"lastAction,omitempty"`
+
+	// PlanUpgrade tracks an in-place plan upgrade of the instance while it is
+	// in progress.
+	// +optional
+	PlanUpgrade *VultrMachinePlanUpgradeStatus `json:"planUpgrade,omitempty"`
+
+	// DataVolumes reports the block storage volumes of the instance.
+	// +optional
+	DataVolumes []DataVolumeStatus `json:"dataVolumes,omitempty"`
+
+	// AppliedAdditionalTags are the additional tags last applied to the
+	// instance or bare metal server. They are removed from the server when
+	// they are removed from the spec, other tags of the server are kept.
+	// +optional
+	AppliedAdditionalTags []string `json:"appliedAdditionalTags,omitempty"`
+}
+
+// MachineAction is an operation on the instance of a VultrMachine requested
+// through the ActionAnnotation annotation.
+type MachineAction string
+
+const (
+	// MachineActionReboot reboots the instance.
+	MachineActionReboot = MachineAction("reboot")
+	// MachineActionHalt powers off the instance.
+	MachineActionHalt = MachineAction("halt")
+	// MachineActionStart powers on the instance.
+	MachineActionStart = MachineAction("start")
+	// MachineActionReinstall reinstalls the instance from its original image
+	// and user data.
+	MachineActionReinstall = MachineAction("reinstall")
+)
+
+// MachineActionResult is the outcome of a MachineAction.
+// +kubebuilder:validation:Enum=Succeeded;Failed;Rejected
+type MachineActionResult string
+
+const (
+	// MachineActionSucceeded is used when the Vultr API accepted the action.
+	MachineActionSucceeded = MachineActionResult("Succeeded")
+	// MachineActionFailed is used when the Vultr API rejected the action.
+	MachineActionFailed = MachineActionResult("Failed")
+	// MachineActionRejected is used when the action was not performed, e.g.
+	// because it is unknown or targets a control plane machine.
+	MachineActionRejected = MachineActionResult("Rejected")
+)
+
+// VolumeType is the type of a Vultr Block Storage volume.
+// +kubebuilder:validation:Enum=NVMe;HDD
+type VolumeType string
+
+const (
+	// VolumeTypeNVMe is high performance NVMe block storage.
+	VolumeTypeNVMe = VolumeType("NVMe")
+	// VolumeTypeHDD is storage optimized HDD block storage.
+	VolumeTypeHDD = VolumeType("HDD")
+)
+
+// VolumeDeletionPolicy defines what happens to a data volume when its
+// machine is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete deletes the volume.
+	VolumeDeletionPolicyDelete = VolumeDeletionPolicy("Delete")
+	// VolumeDeletionPolicyRetain detaches the volume and keeps it.
+	VolumeDeletionPolicyRetain = VolumeDeletionPolicy("Retain")
+)
+
+// DataVolume is a block storage volume attached to the instance of a
+// VultrMachine.
+// +kubebuilder:validation:XValidation:rule="!has(self.mountPath) || has(self.filesystem)",message="mountPath requires filesystem"
+type DataVolume struct {
+	// Label identifies the volume within the machine. The Vultr label of the
+	// volume is the machine name followed by it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Label string `json:"label"`
+
+	// SizeGB is the size of the volume. NVMe volumes are at least 10 GB, HDD
+	// volumes at least 40 GB.
+	// +kubebuilder:validation:Minimum=10
+	SizeGB int `json:"sizeGB"`
+
+	// Type is the storage type of the volume. Defaults to NVMe.
+	// +optional
+	Type VolumeType `json:"type,omitempty"`
+
+	// Filesystem is created on the volume on first boot unless the volume
+	// already has one, either ext4 or xfs. The volume is left unformatted when
+	// empty.
+	// +kubebuilder:validation:Enum=ext4;xfs
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+
+	// MountPath is the absolute path where the volume is mounted. Requires
+	// Filesystem.
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._/-]*$`
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// DeletionPolicy defines whether the volume is deleted along with the
+	// machine. Defaults to Delete.
+	// +optional
+	DeletionPolicy VolumeDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DataVolumeStatus is the observed state of a DataVolume.
+type DataVolumeStatus struct {
+	// Label is the label of the DataVolume.
+	Label string `json:"label"`
+
+	// ID is the ID of the Vultr Block Storage.
+	ID string `json:"id"`
+
+	// MountID identifies the volume on the instance as
+	// /dev/disk/by-id/virtio-<mountID>.
+	// +optional
+	MountID string `json:"mountID,omitempty"`
+
+	// Attached is true once the volume is attached to the instance.
+	Attached bool `json:"attached"`
+}
+
+// VultrMachinePlanUpgradeStatus tracks an in-place plan upgrade.
+type VultrMachinePlanUpgradeStatus struct {
+	// FromPlan is the plan of the instance before the upgrade.
+	FromPlan string `json:"fromPlan"`
+
+	// ToPlan is the plan the instance is upgraded to.
+	ToPlan string `json:"toPlan"`
+
+	// StartTime is when the upgrade was requested.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// VultrMachineActionStatus records a MachineAction.
+type VultrMachineActionStatus struct {
+	// Action is the requested action.
+	Action MachineAction `json:"action"`
+
+	// Time is when the action was handled.
+	Time metav1.Time `json:"time"`
+
+	// Result is the outcome of the action.
+	Result MachineActionResult `json:"result"`
+
+	// Message details the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VultrMachineInitializationStatus provides observations of the VultrMachine initialization process.
+type VultrMachineInitializationStatus struct {
+	// Provisioned is true when the infrastructure provider reports that the Machine's infrastructure is fully provisioned.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// VultrMachineV1Beta2Status groups all the fields that will be added or modified in VultrMachineStatus with the V1Beta2 version.
+// See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
+type VultrMachineV1Beta2Status struct {
+	// Conditions represents the observations of a VultrMachine's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (r *VultrMachine) GetConditions() clusterv1.Conditions {
@@ -144,7 +378,24 @@ func (r *VultrMachine) SetConditions(conditions clusterv1.Conditions) {
 	r.Status.Conditions = conditions
 }
 
+// GetV1Beta2Conditions returns the set of conditions for this object.
+func (r *VultrMachine) GetV1Beta2Conditions() []metav1.Condition {
+	if r.Status.V1Beta2 == nil {
+		return nil
+	}
+	return r.Status.V1Beta2.Conditions
+}
+
+// SetV1Beta2Conditions sets conditions for an API object.
+func (r *VultrMachine) SetV1Beta2Conditions(conditions []metav1.Condition) {
+	if r.Status.V1Beta2 == nil {
+		r.Status.V1Beta2 = &VultrMachineV1Beta2Status{}
+	}
+	r.Status.V1Beta2.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=vultrmachines,scope=Namespaced,categories=cluster-api
 //+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this VultrMachine belongs"
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,15 +29,32 @@ type VultrMachineTemplateSpec struct {
 	Template VultrMachineTemplateResource `json:"template"`
 }
 
+// VultrMachineTemplateStatus defines the observed state of VultrMachineTemplate
+type VultrMachineTemplateStatus struct {
+	// Capacity is the resource capacity of a node created from the template,
+	// looked up from its plan. The cluster-autoscaler uses it to scale
+	// MachineDeployments from zero.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// ObservedGeneration is the generation of the template Capacity was
+	// looked up for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=vultrmachinetemplates,scope=Namespaced,categories=cluster-api,shortName=vmt
+// +kubebuilder:subresource:status
 // VultrMachineTemplate is the Schema for the vultrmachinetemplates API
 
 type VultrMachineTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec VultrMachineTemplateSpec `json:"spec,omitempty"`
+	Spec   VultrMachineTemplateSpec   `json:"spec,omitempty"`
+	Status VultrMachineTemplateStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
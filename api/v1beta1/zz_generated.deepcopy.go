@@ -21,7 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
@@ -47,6 +48,36 @@ func (in *BuildTagParams) DeepCopy() *BuildTagParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolume) DeepCopyInto(out *DataVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolume.
+func (in *DataVolume) DeepCopy() *DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeStatus.
+func (in *DataVolumeStatus) DeepCopy() *DataVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardingRule) DeepCopyInto(out *ForwardingRule) {
 	*out = *in
@@ -199,6 +230,26 @@ func (in *VultrCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterInitializationStatus) DeepCopyInto(out *VultrClusterInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterInitializationStatus.
+func (in *VultrClusterInitializationStatus) DeepCopy() *VultrClusterInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VultrClusterList) DeepCopyInto(out *VultrClusterList) {
 	*out = *in
@@ -236,6 +287,11 @@ func (in *VultrClusterSpec) DeepCopyInto(out *VultrClusterSpec) {
 	*out = *in
 	in.Network.DeepCopyInto(&out.Network)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterSpec.
@@ -269,6 +325,16 @@ func (in *VultrClusterStatus) DeepCopyInto(out *VultrClusterStatus) {
 		}
 	}
 	out.Network = in.Network
+	if in.Initialization != nil {
+		in, out := &in.Initialization, &out.Initialization
+		*out = new(VultrClusterInitializationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.V1Beta2 != nil {
+		in, out := &in.V1Beta2, &out.V1Beta2
+		*out = new(VultrClusterV1Beta2Status)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterStatus.
@@ -371,6 +437,28 @@ func (in *VultrClusterTemplateSpec) DeepCopy() *VultrClusterTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterV1Beta2Status) DeepCopyInto(out *VultrClusterV1Beta2Status) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterV1Beta2Status.
+func (in *VultrClusterV1Beta2Status) DeepCopy() *VultrClusterV1Beta2Status {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterV1Beta2Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VultrLoadBalancer) DeepCopyInto(out *VultrLoadBalancer) {
 	*out = *in
@@ -443,6 +531,42 @@ func (in *VultrMachine) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineActionStatus) DeepCopyInto(out *VultrMachineActionStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineActionStatus.
+func (in *VultrMachineActionStatus) DeepCopy() *VultrMachineActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineInitializationStatus) DeepCopyInto(out *VultrMachineInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineInitializationStatus.
+func (in *VultrMachineInitializationStatus) DeepCopy() *VultrMachineInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VultrMachineList) DeepCopyInto(out *VultrMachineList) {
 	*out = *in
@@ -475,6 +599,22 @@ func (in *VultrMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachinePlanUpgradeStatus) DeepCopyInto(out *VultrMachinePlanUpgradeStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachinePlanUpgradeStatus.
+func (in *VultrMachinePlanUpgradeStatus) DeepCopy() *VultrMachinePlanUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachinePlanUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VultrMachineSpec) DeepCopyInto(out *VultrMachineSpec) {
 	*out = *in
@@ -488,6 +628,21 @@ func (in *VultrMachineSpec) DeepCopyInto(out *VultrMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnableIPv6 != nil {
+		in, out := &in.EnableIPv6, &out.EnableIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineSpec.
@@ -505,7 +660,7 @@ func (in *VultrMachineStatus) DeepCopyInto(out *VultrMachineStatus) {
 	*out = *in
 	if in.Addresses != nil {
 		in, out := &in.Addresses, &out.Addresses
-		*out = make([]v1.NodeAddress, len(*in))
+		*out = make([]corev1.NodeAddress, len(*in))
 		copy(*out, *in)
 	}
 	if in.SubscriptionStatus != nil {
@@ -540,6 +695,36 @@ func (in *VultrMachineStatus) DeepCopyInto(out *VultrMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Initialization != nil {
+		in, out := &in.Initialization, &out.Initialization
+		*out = new(VultrMachineInitializationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.V1Beta2 != nil {
+		in, out := &in.V1Beta2, &out.V1Beta2
+		*out = new(VultrMachineV1Beta2Status)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastAction != nil {
+		in, out := &in.LastAction, &out.LastAction
+		*out = new(VultrMachineActionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlanUpgrade != nil {
+		in, out := &in.PlanUpgrade, &out.PlanUpgrade
+		*out = new(VultrMachinePlanUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolumeStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedAdditionalTags != nil {
+		in, out := &in.AppliedAdditionalTags, &out.AppliedAdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineStatus.
@@ -558,6 +743,7 @@ func (in *VultrMachineTemplate) DeepCopyInto(out *VultrMachineTemplate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplate.
@@ -642,6 +828,50 @@ func (in *VultrMachineTemplateSpec) DeepCopy() *VultrMachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplateStatus) DeepCopyInto(out *VultrMachineTemplateStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplateStatus.
+func (in *VultrMachineTemplateStatus) DeepCopy() *VultrMachineTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineV1Beta2Status) DeepCopyInto(out *VultrMachineV1Beta2Status) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineV1Beta2Status.
+func (in *VultrMachineV1Beta2Status) DeepCopy() *VultrMachineV1Beta2Status {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineV1Beta2Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VultrNetworkResource) DeepCopyInto(out *VultrNetworkResource) {
 	*out = *in
@@ -0,0 +1,459 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+var (
+	_ conversion.Convertible = &VultrCluster{}
+	_ conversion.Convertible = &VultrMachine{}
+	_ conversion.Convertible = &VultrClusterTemplate{}
+	_ conversion.Convertible = &VultrMachineTemplate{}
+)
+
+// ConvertTo converts this VultrCluster to the Hub version (v1beta1).
+func (src *VultrCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.VultrCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertVultrClusterSpecToHub(src.Spec)
+	dst.Status = infrav1.VultrClusterStatus{
+		Network: convertVultrNetworkResourceToHub(src.Status.Network),
+	}
+	if src.Status.Initialization != nil {
+		dst.Status.Initialization = &infrav1.VultrClusterInitializationStatus{
+			Provisioned: src.Status.Initialization.Provisioned,
+		}
+		dst.Status.Ready = src.Status.Initialization.Provisioned != nil && *src.Status.Initialization.Provisioned
+	}
+	if src.Status.Conditions != nil {
+		dst.Status.V1Beta2 = &infrav1.VultrClusterV1Beta2Status{Conditions: src.Status.Conditions}
+	}
+	if src.Status.Deprecated != nil && src.Status.Deprecated.V1Beta1 != nil {
+		dst.Status.Conditions = src.Status.Deprecated.V1Beta1.Conditions
+		dst.Status.FailureReason = src.Status.Deprecated.V1Beta1.FailureReason
+		dst.Status.FailureMessage = src.Status.Deprecated.V1Beta1.FailureMessage
+	}
+
+	// Restore the fields which only exist in the hub. Ready is derived from
+	// the initialization status whenever that is set.
+	restored := &infrav1.VultrCluster{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	if src.Status.Initialization == nil {
+		dst.Status.Ready = restored.Status.Ready
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *VultrCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.VultrCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertVultrClusterSpecFromHub(src.Spec)
+	dst.Status = VultrClusterStatus{
+		Network: convertVultrNetworkResourceFromHub(src.Status.Network),
+	}
+	if src.Status.Initialization != nil {
+		dst.Status.Initialization = &VultrClusterInitializationStatus{
+			Provisioned: src.Status.Initialization.Provisioned,
+		}
+	}
+	if src.Status.V1Beta2 != nil {
+		dst.Status.Conditions = src.Status.V1Beta2.Conditions
+	}
+	if src.Status.Conditions != nil || src.Status.FailureReason != nil || src.Status.FailureMessage != nil {
+		dst.Status.Deprecated = &VultrClusterDeprecatedStatus{
+			V1Beta1: &VultrClusterV1Beta1DeprecatedStatus{
+				Conditions:     src.Status.Conditions,
+				FailureReason:  src.Status.FailureReason,
+				FailureMessage: src.Status.FailureMessage,
+			},
+		}
+	}
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this VultrMachine to the Hub version (v1beta1).
+func (src *VultrMachine) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.VultrMachine)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertVultrMachineSpecToHub(src.Spec)
+	dst.Status = infrav1.VultrMachineStatus{
+		Addresses:             src.Status.Addresses,
+		SubscriptionStatus:    (*infrav1.SubscriptionStatus)(src.Status.SubscriptionStatus),
+		PowerStatus:           (*infrav1.PowerStatus)(src.Status.PowerStatus),
+		ServerState:           (*infrav1.ServerState)(src.Status.ServerState),
+		LastAction:            convertVultrMachineActionStatusToHub(src.Status.LastAction),
+		PlanUpgrade:           (*infrav1.VultrMachinePlanUpgradeStatus)(src.Status.PlanUpgrade),
+		DataVolumes:           convertDataVolumeStatusesToHub(src.Status.DataVolumes),
+		AppliedAdditionalTags: src.Status.AppliedAdditionalTags,
+	}
+	if src.Status.Initialization != nil {
+		dst.Status.Initialization = &infrav1.VultrMachineInitializationStatus{
+			Provisioned: src.Status.Initialization.Provisioned,
+		}
+		dst.Status.Ready = src.Status.Initialization.Provisioned != nil && *src.Status.Initialization.Provisioned
+	}
+	if src.Status.Conditions != nil {
+		dst.Status.V1Beta2 = &infrav1.VultrMachineV1Beta2Status{Conditions: src.Status.Conditions}
+	}
+	if src.Status.Deprecated != nil && src.Status.Deprecated.V1Beta1 != nil {
+		dst.Status.Conditions = src.Status.Deprecated.V1Beta1.Conditions
+		dst.Status.FailureReason = src.Status.Deprecated.V1Beta1.FailureReason
+		dst.Status.FailureMessage = src.Status.Deprecated.V1Beta1.FailureMessage
+	}
+
+	// Restore the fields which only exist in the hub. Ready is derived from
+	// the initialization status whenever that is set.
+	restored := &infrav1.VultrMachine{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	if src.Status.Initialization == nil {
+		dst.Status.Ready = restored.Status.Ready
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *VultrMachine) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.VultrMachine)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertVultrMachineSpecFromHub(src.Spec)
+	dst.Status = VultrMachineStatus{
+		Addresses:             src.Status.Addresses,
+		SubscriptionStatus:    (*SubscriptionStatus)(src.Status.SubscriptionStatus),
+		PowerStatus:           (*PowerStatus)(src.Status.PowerStatus),
+		ServerState:           (*ServerState)(src.Status.ServerState),
+		LastAction:            convertVultrMachineActionStatusFromHub(src.Status.LastAction),
+		PlanUpgrade:           (*VultrMachinePlanUpgradeStatus)(src.Status.PlanUpgrade),
+		DataVolumes:           convertDataVolumeStatusesFromHub(src.Status.DataVolumes),
+		AppliedAdditionalTags: src.Status.AppliedAdditionalTags,
+	}
+	if src.Status.Initialization != nil {
+		dst.Status.Initialization = &VultrMachineInitializationStatus{
+			Provisioned: src.Status.Initialization.Provisioned,
+		}
+	}
+	if src.Status.V1Beta2 != nil {
+		dst.Status.Conditions = src.Status.V1Beta2.Conditions
+	}
+	if src.Status.Conditions != nil || src.Status.FailureReason != nil || src.Status.FailureMessage != nil {
+		dst.Status.Deprecated = &VultrMachineDeprecatedStatus{
+			V1Beta1: &VultrMachineV1Beta1DeprecatedStatus{
+				Conditions:     src.Status.Conditions,
+				FailureReason:  src.Status.FailureReason,
+				FailureMessage: src.Status.FailureMessage,
+			},
+		}
+	}
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this VultrClusterTemplate to the Hub version (v1beta1).
+func (src *VultrClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.VultrClusterTemplate)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Template.Spec = convertVultrClusterSpecToHub(src.Spec.Template.Spec)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *VultrClusterTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.VultrClusterTemplate)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Template.Spec = convertVultrClusterSpecFromHub(src.Spec.Template.Spec)
+
+	return nil
+}
+
+// ConvertTo converts this VultrMachineTemplate to the Hub version (v1beta1).
+func (src *VultrMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.VultrMachineTemplate)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Template.Spec = convertVultrMachineSpecToHub(src.Spec.Template.Spec)
+	dst.Status.Capacity = src.Status.Capacity
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *VultrMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.VultrMachineTemplate)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Template.Spec = convertVultrMachineSpecFromHub(src.Spec.Template.Spec)
+	dst.Status.Capacity = src.Status.Capacity
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+
+	return nil
+}
+
+func convertVultrClusterSpecToHub(in VultrClusterSpec) infrav1.VultrClusterSpec {
+	return infrav1.VultrClusterSpec{
+		Region: in.Region,
+		Network: infrav1.NetworkSpec{
+			APIServerLoadbalancers: convertVultrLoadBalancerToHub(in.Network.APIServerLoadbalancers),
+		},
+		ControlPlaneEndpoint: in.ControlPlaneEndpoint,
+		VPCID:                in.VPCID,
+		AdditionalTags:       in.AdditionalTags,
+	}
+}
+
+func convertVultrClusterSpecFromHub(in infrav1.VultrClusterSpec) VultrClusterSpec {
+	return VultrClusterSpec{
+		Region: in.Region,
+		Network: NetworkSpec{
+			APIServerLoadbalancers: convertVultrLoadBalancerFromHub(in.Network.APIServerLoadbalancers),
+		},
+		ControlPlaneEndpoint: in.ControlPlaneEndpoint,
+		VPCID:                in.VPCID,
+		AdditionalTags:       in.AdditionalTags,
+	}
+}
+
+func convertVultrMachineSpecToHub(in VultrMachineSpec) infrav1.VultrMachineSpec {
+	return infrav1.VultrMachineSpec{
+		ProviderID:         in.ProviderID,
+		Snapshot:           in.Snapshot,
+		PlanID:             in.PlanID,
+		Region:             in.Region,
+		SSHKey:             in.SSHKey,
+		VPCID:              in.VPCID,
+		VPCOnly:            in.VPCOnly,
+		FirewallGroupID:    in.FirewallGroupID,
+		VPC2ID:             in.VPC2ID,
+		StartPolicy:        infrav1.StartPolicy(in.StartPolicy),
+		InPlacePlanUpgrade: in.InPlacePlanUpgrade,
+		EnableIPv6:         in.EnableIPv6,
+		DataVolumes:        convertDataVolumesToHub(in.DataVolumes),
+		MachineType:        infrav1.MachineType(in.MachineType),
+		AdditionalTags:     in.AdditionalTags,
+	}
+}
+
+func convertVultrMachineSpecFromHub(in infrav1.VultrMachineSpec) VultrMachineSpec {
+	return VultrMachineSpec{
+		ProviderID:         in.ProviderID,
+		Snapshot:           in.Snapshot,
+		PlanID:             in.PlanID,
+		Region:             in.Region,
+		SSHKey:             in.SSHKey,
+		VPCID:              in.VPCID,
+		VPCOnly:            in.VPCOnly,
+		FirewallGroupID:    in.FirewallGroupID,
+		VPC2ID:             in.VPC2ID,
+		StartPolicy:        StartPolicy(in.StartPolicy),
+		InPlacePlanUpgrade: in.InPlacePlanUpgrade,
+		EnableIPv6:         in.EnableIPv6,
+		DataVolumes:        convertDataVolumesFromHub(in.DataVolumes),
+		MachineType:        MachineType(in.MachineType),
+		AdditionalTags:     in.AdditionalTags,
+	}
+}
+
+func convertDataVolumesToHub(in []DataVolume) []infrav1.DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := make([]infrav1.DataVolume, 0, len(in))
+	for _, v := range in {
+		out = append(out, infrav1.DataVolume{
+			Label:          v.Label,
+			SizeGB:         v.SizeGB,
+			Type:           infrav1.VolumeType(v.Type),
+			Filesystem:     v.Filesystem,
+			MountPath:      v.MountPath,
+			DeletionPolicy: infrav1.VolumeDeletionPolicy(v.DeletionPolicy),
+		})
+	}
+	return out
+}
+
+func convertDataVolumesFromHub(in []infrav1.DataVolume) []DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := make([]DataVolume, 0, len(in))
+	for _, v := range in {
+		out = append(out, DataVolume{
+			Label:          v.Label,
+			SizeGB:         v.SizeGB,
+			Type:           VolumeType(v.Type),
+			Filesystem:     v.Filesystem,
+			MountPath:      v.MountPath,
+			DeletionPolicy: VolumeDeletionPolicy(v.DeletionPolicy),
+		})
+	}
+	return out
+}
+
+func convertDataVolumeStatusesToHub(in []DataVolumeStatus) []infrav1.DataVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := make([]infrav1.DataVolumeStatus, 0, len(in))
+	for _, v := range in {
+		out = append(out, infrav1.DataVolumeStatus(v))
+	}
+	return out
+}
+
+func convertDataVolumeStatusesFromHub(in []infrav1.DataVolumeStatus) []DataVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := make([]DataVolumeStatus, 0, len(in))
+	for _, v := range in {
+		out = append(out, DataVolumeStatus(v))
+	}
+	return out
+}
+
+func convertVultrMachineActionStatusToHub(in *VultrMachineActionStatus) *infrav1.VultrMachineActionStatus {
+	if in == nil {
+		return nil
+	}
+	return &infrav1.VultrMachineActionStatus{
+		Action:  infrav1.MachineAction(in.Action),
+		Time:    in.Time,
+		Result:  infrav1.MachineActionResult(in.Result),
+		Message: in.Message,
+	}
+}
+
+func convertVultrMachineActionStatusFromHub(in *infrav1.VultrMachineActionStatus) *VultrMachineActionStatus {
+	if in == nil {
+		return nil
+	}
+	return &VultrMachineActionStatus{
+		Action:  MachineAction(in.Action),
+		Time:    in.Time,
+		Result:  MachineActionResult(in.Result),
+		Message: in.Message,
+	}
+}
+
+func convertVultrLoadBalancerToHub(in VultrLoadBalancer) infrav1.VultrLoadBalancer {
+	out := infrav1.VultrLoadBalancer{
+		ID:          in.ID,
+		DateCreated: in.DateCreated,
+		Region:      in.Region,
+		Label:       in.Label,
+		Status:      in.Status,
+		IPV4:        in.IPV4,
+		IPV6:        in.IPV6,
+		Instances:   in.Instances,
+		Nodes:       in.Nodes,
+		HealthCheck: (*infrav1.HealthCheck)(in.HealthCheck),
+		SSLInfo:     in.SSLInfo,
+	}
+	if in.GenericInfo != nil {
+		out.GenericInfo = &infrav1.GenericInfo{
+			BalancingAlgorithm: in.GenericInfo.BalancingAlgorithm,
+			SSLRedirect:        in.GenericInfo.SSLRedirect,
+			StickySessions:     (*infrav1.StickySessions)(in.GenericInfo.StickySessions),
+			ProxyProtocol:      in.GenericInfo.ProxyProtocol,
+			VPC:                in.GenericInfo.VPC,
+		}
+	}
+	for _, rule := range in.ForwardingRules {
+		out.ForwardingRules = append(out.ForwardingRules, infrav1.ForwardingRule(rule))
+	}
+	for _, rule := range in.FirewallRules {
+		out.FirewallRules = append(out.FirewallRules, infrav1.LBFirewallRule(rule))
+	}
+	return out
+}
+
+func convertVultrLoadBalancerFromHub(in infrav1.VultrLoadBalancer) VultrLoadBalancer {
+	out := VultrLoadBalancer{
+		ID:          in.ID,
+		DateCreated: in.DateCreated,
+		Region:      in.Region,
+		Label:       in.Label,
+		Status:      in.Status,
+		IPV4:        in.IPV4,
+		IPV6:        in.IPV6,
+		Instances:   in.Instances,
+		Nodes:       in.Nodes,
+		HealthCheck: (*HealthCheck)(in.HealthCheck),
+		SSLInfo:     in.SSLInfo,
+	}
+	if in.GenericInfo != nil {
+		out.GenericInfo = &GenericInfo{
+			BalancingAlgorithm: in.GenericInfo.BalancingAlgorithm,
+			SSLRedirect:        in.GenericInfo.SSLRedirect,
+			StickySessions:     (*StickySessions)(in.GenericInfo.StickySessions),
+			ProxyProtocol:      in.GenericInfo.ProxyProtocol,
+			VPC:                in.GenericInfo.VPC,
+		}
+	}
+	for _, rule := range in.ForwardingRules {
+		out.ForwardingRules = append(out.ForwardingRules, ForwardingRule(rule))
+	}
+	for _, rule := range in.FirewallRules {
+		out.FirewallRules = append(out.FirewallRules, LBFirewallRule(rule))
+	}
+	return out
+}
+
+func convertVultrNetworkResourceToHub(in VultrNetworkResource) infrav1.VultrNetworkResource {
+	ref := in.APIServerLoadbalancersRef
+	return infrav1.VultrNetworkResource{
+		APIServerLoadbalancersRef: infrav1.VultrResourceReference{
+			ResourceID:                 ref.ResourceID,
+			ResourceSubscriptionStatus: infrav1.SubscriptionStatus(ref.ResourceSubscriptionStatus),
+			ResourcePowerStatus:        infrav1.PowerStatus(ref.ResourcePowerStatus),
+			ResourceServerState:        infrav1.ServerState(ref.ResourceServerState),
+		},
+	}
+}
+
+func convertVultrNetworkResourceFromHub(in infrav1.VultrNetworkResource) VultrNetworkResource {
+	ref := in.APIServerLoadbalancersRef
+	return VultrNetworkResource{
+		APIServerLoadbalancersRef: VultrResourceReference{
+			ResourceID:                 ref.ResourceID,
+			ResourceSubscriptionStatus: SubscriptionStatus(ref.ResourceSubscriptionStatus),
+			ResourcePowerStatus:        PowerStatus(ref.ResourcePowerStatus),
+			ResourceServerState:        ServerState(ref.ResourceServerState),
+		},
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+func TestVultrClusterConversion(t *testing.T) {
+	hub := &infrav1.VultrCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: infrav1.VultrClusterSpec{
+			Region: "ewr",
+			Network: infrav1.NetworkSpec{
+				APIServerLoadbalancers: infrav1.VultrLoadBalancer{
+					ID:              "lb-1",
+					HealthCheck:     &infrav1.HealthCheck{Port: 6443, CheckInterval: 15},
+					GenericInfo:     &infrav1.GenericInfo{BalancingAlgorithm: "roundrobin", StickySessions: &infrav1.StickySessions{CookieName: "c"}},
+					ForwardingRules: []infrav1.ForwardingRule{{FrontendPort: 6443, BackendPort: 6443}},
+					FirewallRules:   []infrav1.LBFirewallRule{{Port: 6443, IPType: "v4", Source: "0.0.0.0/0"}},
+				},
+			},
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.0.2.1", Port: 6443},
+			VPCID:                "vpc-1",
+			AdditionalTags:       []string{"cost-center:42"},
+		},
+		Status: infrav1.VultrClusterStatus{
+			Ready:          true,
+			FailureReason:  ptr.To(capierrors.CreateClusterError),
+			FailureMessage: ptr.To("failed"),
+			Conditions:     clusterv1.Conditions{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}},
+			Network: infrav1.VultrNetworkResource{
+				APIServerLoadbalancersRef: infrav1.VultrResourceReference{ResourceID: "lb-1", ResourceSubscriptionStatus: infrav1.SubscriptionStatusActive},
+			},
+			Initialization: &infrav1.VultrClusterInitializationStatus{Provisioned: ptr.To(true)},
+			V1Beta2: &infrav1.VultrClusterV1Beta2Status{
+				Conditions: []metav1.Condition{{Type: clusterv1.ReadyV1Beta2Condition, Status: metav1.ConditionTrue, Reason: "Ready"}},
+			},
+		},
+	}
+
+	t.Run("hub to spoke to hub", func(t *testing.T) {
+		g := NewWithT(t)
+
+		spoke := &VultrCluster{}
+		g.Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+		g.Expect(spoke.Status.Initialization.Provisioned).To(Equal(ptr.To(true)))
+		g.Expect(spoke.Status.Conditions).To(Equal(hub.Status.V1Beta2.Conditions))
+		g.Expect(spoke.Status.Deprecated.V1Beta1.FailureMessage).To(Equal(ptr.To("failed")))
+
+		restored := &infrav1.VultrCluster{}
+		g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+		g.Expect(restored.Spec).To(Equal(hub.Spec))
+		g.Expect(restored.Status).To(Equal(hub.Status))
+	})
+
+	t.Run("spoke to hub", func(t *testing.T) {
+		g := NewWithT(t)
+
+		spoke := &VultrCluster{
+			Status: VultrClusterStatus{
+				Initialization: &VultrClusterInitializationStatus{Provisioned: ptr.To(true)},
+			},
+		}
+		converted := &infrav1.VultrCluster{}
+		g.Expect(spoke.ConvertTo(converted)).To(Succeed())
+		g.Expect(converted.Status.Ready).To(BeTrue())
+	})
+
+	t.Run("spoke provisioned after down-conversion", func(t *testing.T) {
+		g := NewWithT(t)
+
+		provisioning := hub.DeepCopy()
+		provisioning.Status.Ready = false
+		provisioning.Status.Initialization.Provisioned = ptr.To(false)
+		spoke := &VultrCluster{}
+		g.Expect(spoke.ConvertFrom(provisioning)).To(Succeed())
+
+		// The annotation still holds Ready=false, the initialization status wins.
+		spoke.Status.Initialization.Provisioned = ptr.To(true)
+		converted := &infrav1.VultrCluster{}
+		g.Expect(spoke.ConvertTo(converted)).To(Succeed())
+		g.Expect(converted.Status.Ready).To(BeTrue())
+
+		// Without an initialization status Ready is restored.
+		spoke.Status.Initialization = nil
+		g.Expect(spoke.ConvertTo(converted)).To(Succeed())
+		g.Expect(converted.Status.Ready).To(BeFalse())
+	})
+}
+
+func TestVultrMachineConversion(t *testing.T) {
+	g := NewWithT(t)
+
+	hub := &infrav1.VultrMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: infrav1.VultrMachineSpec{
+			ProviderID:         ptr.To("vultr://1234"),
+			Snapshot:           "snap",
+			PlanID:             "vc2-2c-4gb",
+			Region:             "ewr",
+			SSHKey:             []string{"key"},
+			VPCID:              "vpc-1",
+			FirewallGroupID:    "fw-1",
+			StartPolicy:        infrav1.StartPolicyAlways,
+			InPlacePlanUpgrade: true,
+			EnableIPv6:         ptr.To(false),
+			DataVolumes: []infrav1.DataVolume{{
+				Label:          "etcd",
+				SizeGB:         40,
+				Type:           infrav1.VolumeTypeNVMe,
+				Filesystem:     "ext4",
+				MountPath:      "/var/lib/etcd",
+				DeletionPolicy: infrav1.VolumeDeletionPolicyRetain,
+			}},
+			MachineType:    infrav1.MachineTypeInstance,
+			AdditionalTags: []string{"team:platform"},
+		},
+		Status: infrav1.VultrMachineStatus{
+			Ready:              true,
+			Addresses:          []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "192.0.2.1"}},
+			SubscriptionStatus: ptr.To(infrav1.SubscriptionStatusActive),
+			PowerStatus:        ptr.To(infrav1.PowerStatusRunning),
+			ServerState:        ptr.To(infrav1.ServerStateOK),
+			LastAction: &infrav1.VultrMachineActionStatus{
+				Action: infrav1.MachineActionReboot,
+				Time:   metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Result: infrav1.MachineActionSucceeded,
+			},
+			PlanUpgrade: &infrav1.VultrMachinePlanUpgradeStatus{
+				FromPlan:  "vc2-1c-2gb",
+				ToPlan:    "vc2-2c-4gb",
+				StartTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			DataVolumes:           []infrav1.DataVolumeStatus{{Label: "etcd", ID: "block-1", MountID: "ewr-1234", Attached: true}},
+			AppliedAdditionalTags: []string{"team:platform"},
+			Conditions:            clusterv1.Conditions{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}},
+			Initialization:        &infrav1.VultrMachineInitializationStatus{Provisioned: ptr.To(true)},
+			V1Beta2: &infrav1.VultrMachineV1Beta2Status{
+				Conditions: []metav1.Condition{{Type: clusterv1.ReadyV1Beta2Condition, Status: metav1.ConditionTrue, Reason: "Ready"}},
+			},
+		},
+	}
+
+	spoke := &VultrMachine{}
+	g.Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+	g.Expect(spoke.Spec.ProviderID).To(Equal(hub.Spec.ProviderID))
+	g.Expect(spoke.Status.Conditions).To(Equal(hub.Status.V1Beta2.Conditions))
+
+	restored := &infrav1.VultrMachine{}
+	g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+	g.Expect(restored.Spec).To(Equal(hub.Spec))
+	g.Expect(restored.Status).To(Equal(hub.Status))
+
+	// A v1beta2 client marking the machine provisioned is not overridden by
+	// the Ready value of the annotation.
+	provisioning := hub.DeepCopy()
+	provisioning.Status.Ready = false
+	provisioning.Status.Initialization.Provisioned = ptr.To(false)
+	spoke = &VultrMachine{}
+	g.Expect(spoke.ConvertFrom(provisioning)).To(Succeed())
+	spoke.Status.Initialization.Provisioned = ptr.To(true)
+	g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+	g.Expect(restored.Status.Ready).To(BeTrue())
+}
+
+func TestVultrMachineTemplateConversion(t *testing.T) {
+	g := NewWithT(t)
+
+	hub := &infrav1.VultrMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+		Spec: infrav1.VultrMachineTemplateSpec{
+			Template: infrav1.VultrMachineTemplateResource{
+				Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
+			},
+		},
+		Status: infrav1.VultrMachineTemplateStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			ObservedGeneration: 2,
+		},
+	}
+
+	spoke := &VultrMachineTemplate{}
+	g.Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+	g.Expect(spoke.Status.Capacity).To(Equal(hub.Status.Capacity))
+
+	restored := &infrav1.VultrMachineTemplate{}
+	g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+	g.Expect(restored.Spec).To(Equal(hub.Spec))
+	g.Expect(restored.Status).To(Equal(hub.Status))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
+)
+
+// VultrClusterSpec defines the desired state of VultrCluster
+type VultrClusterSpec struct {
+	// The Vultr Region (DCID) the cluster lives on
+	Region string `json:"region"`
+
+	// NetworkSpec encapsulates all things related to Vultr network.
+	// +optional
+	Network NetworkSpec `json:"network"`
+
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+
+	// VPCID is the Vultr VPC ID used for the cluster's load balancer.
+	// +optional
+	VPCID string `json:"vpc_id,omitempty"`
+
+	// AdditionalTags are added to the Vultr instances and bare metal servers
+	// of all machines of the cluster, besides the tags of the provider.
+	// Changes are applied to existing servers.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self.all(t, !t.startsWith('sigs-k8s-io:capvultr') && !t.startsWith('name:'))",message="tags starting with sigs-k8s-io:capvultr or name: are reserved for the provider"
+	AdditionalTags []string `json:"additionalTags,omitempty"`
+}
+
+// VultrClusterStatus defines the observed state of VultrCluster
+type VultrClusterStatus struct {
+	// Conditions represents the observations of a VultrCluster's current state.
+	// Known condition types are Ready, Paused, VPCReady and LoadBalancerReady.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Initialization provides observations of the VultrCluster initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Cluster provisioning.
+	// +optional
+	Initialization *VultrClusterInitializationStatus `json:"initialization,omitempty"`
+
+	// Network encapsulates all things related to the Vultr network.
+	// +optional
+	Network VultrNetworkResource `json:"network,omitempty"`
+
+	// Deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
+	// +optional
+	Deprecated *VultrClusterDeprecatedStatus `json:"deprecated,omitempty"`
+}
+
+// VultrClusterInitializationStatus provides observations of the VultrCluster initialization process.
+type VultrClusterInitializationStatus struct {
+	// Provisioned is true when the infrastructure provider reports that the cluster infrastructure is fully provisioned.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// VultrClusterDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
+type VultrClusterDeprecatedStatus struct {
+	// V1Beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+	// +optional
+	V1Beta1 *VultrClusterV1Beta1DeprecatedStatus `json:"v1beta1,omitempty"`
+}
+
+// VultrClusterV1Beta1DeprecatedStatus groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+type VultrClusterV1Beta1DeprecatedStatus struct {
+	// Conditions defines current service state of the VultrCluster.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// FailureReason will be set in the event that there is a terminal problem
+	// reconciling the VultrCluster and will contain a succinct value suitable
+	// for machine interpretation.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	FailureReason *errors.ClusterStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set in the event that there is a terminal problem
+	// reconciling the VultrCluster and will contain a more verbose string suitable
+	// for logging and human consumption.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=vultrclusters,scope=Namespaced,categories=cluster-api
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this VultrCluster belongs"
+//+kubebuilder:printcolumn:name="Provisioned",type="string",JSONPath=".status.initialization.provisioned",description="Cluster infrastructure is provisioned"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Cluster infrastructure is ready for Vultr instances"
+//+kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint.host",description="API Endpoint",priority=1
+
+// VultrCluster is the Schema for the vultrclusters API
+type VultrCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VultrClusterSpec   `json:"spec,omitempty"`
+	Status VultrClusterStatus `json:"status,omitempty"`
+}
+
+// GetV1Beta1Conditions returns the set of conditions for this object.
+func (r *VultrCluster) GetV1Beta1Conditions() clusterv1.Conditions {
+	if r.Status.Deprecated == nil || r.Status.Deprecated.V1Beta1 == nil {
+		return nil
+	}
+	return r.Status.Deprecated.V1Beta1.Conditions
+}
+
+// SetV1Beta1Conditions sets the conditions on this object.
+func (r *VultrCluster) SetV1Beta1Conditions(conditions clusterv1.Conditions) {
+	if r.Status.Deprecated == nil {
+		r.Status.Deprecated = &VultrClusterDeprecatedStatus{}
+	}
+	if r.Status.Deprecated.V1Beta1 == nil {
+		r.Status.Deprecated.V1Beta1 = &VultrClusterV1Beta1DeprecatedStatus{}
+	}
+	r.Status.Deprecated.V1Beta1.Conditions = conditions
+}
+
+// GetConditions returns the set of conditions for this object.
+func (r *VultrCluster) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (r *VultrCluster) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// VultrClusterList contains a list of VultrCluster
+type VultrClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VultrCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VultrCluster{}, &VultrClusterList{})
+}
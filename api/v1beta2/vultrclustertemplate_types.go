@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VultrClusterTemplateSpec defines the desired state of VultrClusterTemplate
+type VultrClusterTemplateSpec struct {
+	Template VultrClusterTemplateResource `json:"template"`
+}
+
+// VultrClusterTemplateResource contains spec for VultrClusterSpec.
+type VultrClusterTemplateResource struct {
+	// Spec is the specification of the VultrClusters created from the template.
+	// The load balancer and control plane endpoint are created per cluster by
+	// the controller, so they must be left empty.
+	// +kubebuilder:validation:XValidation:rule="!has(self.controlPlaneEndpoint) || (self.controlPlaneEndpoint.host == '' && self.controlPlaneEndpoint.port == 0)",message="controlPlaneEndpoint is set by the controller and must be empty in templates"
+	// +kubebuilder:validation:XValidation:rule="!has(self.network) || !has(self.network.apiServerLoadbalancers) || !(has(self.network.apiServerLoadbalancers.id) || has(self.network.apiServerLoadbalancers.date_created) || has(self.network.apiServerLoadbalancers.status) || has(self.network.apiServerLoadbalancers.ipv4) || has(self.network.apiServerLoadbalancers.ipv6) || has(self.network.apiServerLoadbalancers.instances))",message="the load balancer id, status, addresses and instances are set by the controller and must not be set in templates"
+	Spec VultrClusterSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=vultrclustertemplates,scope=Namespaced,categories=cluster-api,shortName=vct
+
+// VultrClusterTemplate is the Schema for the vultrclustertemplates API
+type VultrClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VultrClusterTemplateSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VultrClusterTemplateList contains a list of VultrClusterTemplate
+type VultrClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VultrClusterTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VultrClusterTemplate{}, &VultrClusterTemplateList{})
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
+)
+
+// VultrMachineSpec defines the desired state of VultrMachine
+// +kubebuilder:validation:XValidation:rule="!has(self.machineType) || self.machineType != 'BareMetal' || (!has(self.dataVolumes) && !has(self.firewall_group_id))",message="bare metal machines support neither data volumes nor firewall groups"
+// +kubebuilder:validation:XValidation:rule="(has(self.machineType) ? self.machineType : 'Instance') == (has(oldSelf.machineType) ? oldSelf.machineType : 'Instance')",message="machineType is immutable"
+type VultrMachineSpec struct {
+	// ProviderID is the unique identifier as specified by the cloud provider.
+	// +optional
+	ProviderID *string `json:"providerID,omitempty"`
+
+	//The Vultr snapshot_id to use when deploying this instance.
+	Snapshot string `json:"snapshot_id,omitempty"`
+
+	// PlanID is the id of Vultr VPS plan (VPSPLANID).
+	PlanID string `json:"planID,omitempty"`
+
+	// The Vultr Region (DCID) the cluster lives on
+	// +kubebuilder:validation:Required
+	Region string `json:"region"`
+
+	// sshKey is the name of the ssh key to attach to the instance.
+	// +optional
+	SSHKey []string `json:"sshKey,omitempty"`
+
+	// VPCID is the id of the VPC to be attached.
+	// +optional
+	VPCID string `json:"vpc_id,omitempty"`
+
+	//VPCOnly indicates that the VPS will not receive a public IP or public NIC when true.
+	VPCOnly bool `json:"vpc_only,omitempty"`
+
+	//The Vultr firewall group ID to attach to the instance
+	// +optional
+	FirewallGroupID string `json:"firewall_group_id,omitempty"`
+
+	// VPC2ID is the id of the VPC2.0 to be attached.
+	// Deprecated: VPC2 is no longer supported and functionality will cease in a
+	// future release
+	// +optional
+	VPC2ID string `json:"vpc2_id,omitempty"`
+
+	// StartPolicy defines whether the instance is started again when it was
+	// stopped outside of Cluster API, e.g. in the Vultr console. Defaults to
+	// Never.
+	// +optional
+	StartPolicy StartPolicy `json:"startPolicy,omitempty"`
+
+	// InPlacePlanUpgrade resizes the existing instance when PlanID changes,
+	// instead of leaving it on its current plan. Only upgrades to a larger
+	// plan are possible.
+	// +optional
+	InPlacePlanUpgrade bool `json:"inPlacePlanUpgrade,omitempty"`
+
+	// EnableIPv6 assigns a public IPv6 address to the instance. Defaults to
+	// true, has no effect when VPCOnly is set.
+	// +optional
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+
+	// DataVolumes are Vultr Block Storage volumes created in the region of the
+	// machine and attached to its instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=label
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+
+	// MachineType is the kind of Vultr server backing the machine. BareMetal
+	// machines use a bare metal plan, e.g. vbm-4c-32gb, and can only be
+	// worker machines. Defaults to Instance. Instances and bare metal servers
+	// both have provider IDs of the form vultr://<id>, as set on their Nodes
+	// by the Vultr cloud controller manager, so the machine type is what
+	// tells them apart.
+	// +optional
+	MachineType MachineType `json:"machineType,omitempty"`
+
+	// AdditionalTags are added to the instance or bare metal server of the
+	// machine, besides the additional tags of the VultrCluster and the tags
+	// of the provider. Changes are applied to the existing server.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self.all(t, !t.startsWith('sigs-k8s-io:capvultr') && !t.startsWith('name:'))",message="tags starting with sigs-k8s-io:capvultr or name: are reserved for the provider"
+	AdditionalTags []string `json:"additionalTags,omitempty"`
+}
+
+// VultrMachineStatus defines the observed state of VultrMachine
+type VultrMachineStatus struct {
+	// Conditions represents the observations of a VultrMachine's current state.
+	// Known condition types are Ready, Paused, BootstrapDataAvailable, PreflightChecksPassed, VPCReady,
+	// FirewallReady, InstanceProvisioned, InstanceRunning, LoadBalancerAttached, PlanUpToDate and
+	// DataVolumesReady.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Initialization provides observations of the VultrMachine initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Machine provisioning.
+	// +optional
+	Initialization *VultrMachineInitializationStatus `json:"initialization,omitempty"`
+
+	// Addresses contains the Vultr instance associated addresses.
+	// +optional
+	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
+
+	// SubscriptionStatus represents the status of subscription.
+	// +optional
+	SubscriptionStatus *SubscriptionStatus `json:"subscriptionStatus,omitempty"`
+
+	// PowerStatus represents that the VPS is powerd on or not
+	// +optional
+	PowerStatus *PowerStatus `json:"powerStatus,omitempty"`
+
+	// ServerState represents a detail of server state.
+	// +optional
+	ServerState *ServerState `json:"serverState,omitempty"`
+
+	// LastAction is the last action requested through the action annotation.
+	// +optional
+	LastAction *VultrMachineActionStatus `json:"lastAction,omitempty"`
+
+	// PlanUpgrade tracks an in-place plan upgrade of the instance while it is
+	// in progress.
+	// +optional
+	PlanUpgrade *VultrMachinePlanUpgradeStatus `json:"planUpgrade,omitempty"`
+
+	// DataVolumes reports the block storage volumes of the instance.
+	// +optional
+	DataVolumes []DataVolumeStatus `json:"dataVolumes,omitempty"`
+
+	// AppliedAdditionalTags are the additional tags last applied to the
+	// instance or bare metal server. They are removed from the server when
+	// they are removed from the spec, other tags of the server are kept.
+	// +optional
+	AppliedAdditionalTags []string `json:"appliedAdditionalTags,omitempty"`
+
+	// Deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
+	// +optional
+	Deprecated *VultrMachineDeprecatedStatus `json:"deprecated,omitempty"`
+}
+
+// MachineAction is an operation on the instance of a VultrMachine requested
+// through the infrastructure.cluster.x-k8s.io/vultr-action annotation.
+type MachineAction string
+
+const (
+	// MachineActionReboot reboots the instance.
+	MachineActionReboot = MachineAction("reboot")
+	// MachineActionHalt powers off the instance.
+	MachineActionHalt = MachineAction("halt")
+	// MachineActionStart powers on the instance.
+	MachineActionStart = MachineAction("start")
+	// MachineActionReinstall reinstalls the instance from its original image
+	// and user data.
+	MachineActionReinstall = MachineAction("reinstall")
+)
+
+// MachineActionResult is the outcome of a MachineAction.
+// +kubebuilder:validation:Enum=Succeeded;Failed;Rejected
+type MachineActionResult string
+
+const (
+	// MachineActionSucceeded is used when the Vultr API accepted the action.
+	MachineActionSucceeded = MachineActionResult("Succeeded")
+	// MachineActionFailed is used when the Vultr API rejected the action.
+	MachineActionFailed = MachineActionResult("Failed")
+	// MachineActionRejected is used when the action was not performed, e.g.
+	// because it is unknown or targets a control plane machine.
+	MachineActionRejected = MachineActionResult("Rejected")
+)
+
+// VolumeType is the type of a Vultr Block Storage volume.
+// +kubebuilder:validation:Enum=NVMe;HDD
+type VolumeType string
+
+const (
+	// VolumeTypeNVMe is high performance NVMe block storage.
+	VolumeTypeNVMe = VolumeType("NVMe")
+	// VolumeTypeHDD is storage optimized HDD block storage.
+	VolumeTypeHDD = VolumeType("HDD")
+)
+
+// VolumeDeletionPolicy defines what happens to a data volume when its
+// machine is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete deletes the volume.
+	VolumeDeletionPolicyDelete = VolumeDeletionPolicy("Delete")
+	// VolumeDeletionPolicyRetain detaches the volume and keeps it.
+	VolumeDeletionPolicyRetain = VolumeDeletionPolicy("Retain")
+)
+
+// DataVolume is a block storage volume attached to the instance of a
+// VultrMachine.
+// +kubebuilder:validation:XValidation:rule="!has(self.mountPath) || has(self.filesystem)",message="mountPath requires filesystem"
+type DataVolume struct {
+	// Label identifies the volume within the machine. The Vultr label of the
+	// volume is the machine name followed by it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Label string `json:"label"`
+
+	// SizeGB is the size of the volume. NVMe volumes are at least 10 GB, HDD
+	// volumes at least 40 GB.
+	// +kubebuilder:validation:Minimum=10
+	SizeGB int `json:"sizeGB"`
+
+	// Type is the storage type of the volume. Defaults to NVMe.
+	// +optional
+	Type VolumeType `json:"type,omitempty"`
+
+	// Filesystem is created on the volume on first boot unless the volume
+	// already has one, either ext4 or xfs. The volume is left unformatted when
+	// empty.
+	// +kubebuilder:validation:Enum=ext4;xfs
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+
+	// MountPath is the absolute path where the volume is mounted. Requires
+	// Filesystem.
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._/-]*$`
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// DeletionPolicy defines whether the volume is deleted along with the
+	// machine. Defaults to Delete.
+	// +optional
+	DeletionPolicy VolumeDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DataVolumeStatus is the observed state of a DataVolume.
+type DataVolumeStatus struct {
+	// Label is the label of the DataVolume.
+	Label string `json:"label"`
+
+	// ID is the ID of the Vultr Block Storage.
+	ID string `json:"id"`
+
+	// MountID identifies the volume on the instance as
+	// /dev/disk/by-id/virtio-<mountID>.
+	// +optional
+	MountID string `json:"mountID,omitempty"`
+
+	// Attached is true once the volume is attached to the instance.
+	Attached bool `json:"attached"`
+}
+
+// VultrMachinePlanUpgradeStatus tracks an in-place plan upgrade.
+type VultrMachinePlanUpgradeStatus struct {
+	// FromPlan is the plan of the instance before the upgrade.
+	FromPlan string `json:"fromPlan"`
+
+	// ToPlan is the plan the instance is upgraded to.
+	ToPlan string `json:"toPlan"`
+
+	// StartTime is when the upgrade was requested.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// VultrMachineActionStatus records a MachineAction.
+type VultrMachineActionStatus struct {
+	// Action is the requested action.
+	Action MachineAction `json:"action"`
+
+	// Time is when the action was handled.
+	Time metav1.Time `json:"time"`
+
+	// Result is the outcome of the action.
+	Result MachineActionResult `json:"result"`
+
+	// Message details the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VultrMachineInitializationStatus provides observations of the VultrMachine initialization process.
+type VultrMachineInitializationStatus struct {
+	// Provisioned is true when the infrastructure provider reports that the Machine's infrastructure is fully provisioned.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// VultrMachineDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
+type VultrMachineDeprecatedStatus struct {
+	// V1Beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+	// +optional
+	V1Beta1 *VultrMachineV1Beta1DeprecatedStatus `json:"v1beta1,omitempty"`
+}
+
+// VultrMachineV1Beta1DeprecatedStatus groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+type VultrMachineV1Beta1DeprecatedStatus struct {
+	// Conditions defines current service state of the VultrMachine.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// FailureReason will be set in the event that there is a terminal problem
+	// reconciling the VultrMachine and will contain a succinct value suitable
+	// for machine interpretation.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	FailureReason *errors.MachineStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set in the event that there is a terminal problem
+	// reconciling the VultrMachine and will contain a more verbose string suitable
+	// for logging and human consumption.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=vultrmachines,scope=Namespaced,categories=cluster-api
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this VultrMachine belongs"
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.subscriptionStatus",description="Vultr instance state"
+//+kubebuilder:printcolumn:name="Provisioned",type="string",JSONPath=".status.initialization.provisioned",description="Machine infrastructure is provisioned"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Machine ready status"
+//+kubebuilder:printcolumn:name="InstanceID",type="string",JSONPath=".spec.providerID",description="Vultr instance ID"
+//+kubebuilder:printcolumn:name="Machine",type="string",JSONPath=".metadata.ownerReferences[?(@.kind==\"Machine\")].name",description="Machine object which owns with this VultrMachine"
+
+// VultrMachine is the Schema for the vultrmachines API
+type VultrMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VultrMachineSpec   `json:"spec,omitempty"`
+	Status VultrMachineStatus `json:"status,omitempty"`
+}
+
+// GetV1Beta1Conditions returns the set of conditions for this object.
+func (r *VultrMachine) GetV1Beta1Conditions() clusterv1.Conditions {
+	if r.Status.Deprecated == nil || r.Status.Deprecated.V1Beta1 == nil {
+		return nil
+	}
+	return r.Status.Deprecated.V1Beta1.Conditions
+}
+
+// SetV1Beta1Conditions sets the conditions on this object.
+func (r *VultrMachine) SetV1Beta1Conditions(conditions clusterv1.Conditions) {
+	if r.Status.Deprecated == nil {
+		r.Status.Deprecated = &VultrMachineDeprecatedStatus{}
+	}
+	if r.Status.Deprecated.V1Beta1 == nil {
+		r.Status.Deprecated.V1Beta1 = &VultrMachineV1Beta1DeprecatedStatus{}
+	}
+	r.Status.Deprecated.V1Beta1.Conditions = conditions
+}
+
+// GetConditions returns the set of conditions for this object.
+func (r *VultrMachine) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (r *VultrMachine) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// VultrMachineList contains a list of VultrMachine
+type VultrMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VultrMachine `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VultrMachine{}, &VultrMachineList{})
+}
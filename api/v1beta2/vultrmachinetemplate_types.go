@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VultrMachineTemplateSpec defines the desired state of VultrMachineTemplate
+type VultrMachineTemplateSpec struct {
+	Template VultrMachineTemplateResource `json:"template"`
+}
+
+// VultrMachineTemplateStatus defines the observed state of VultrMachineTemplate
+type VultrMachineTemplateStatus struct {
+	// Capacity is the resource capacity of a node created from the template,
+	// looked up from its plan. The cluster-autoscaler uses it to scale
+	// MachineDeployments from zero.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// ObservedGeneration is the generation of the template Capacity was
+	// looked up for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=vultrmachinetemplates,scope=Namespaced,categories=cluster-api,shortName=vmt
+//+kubebuilder:subresource:status
+
+// VultrMachineTemplate is the Schema for the vultrmachinetemplates API
+type VultrMachineTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VultrMachineTemplateSpec   `json:"spec,omitempty"`
+	Status VultrMachineTemplateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VultrMachineTemplateList contains a list of VultrMachineTemplate
+type VultrMachineTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VultrMachineTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VultrMachineTemplate{}, &VultrMachineTemplateList{})
+}
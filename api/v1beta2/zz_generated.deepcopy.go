@@ -0,0 +1,905 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolume) DeepCopyInto(out *DataVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolume.
+func (in *DataVolume) DeepCopy() *DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeStatus.
+func (in *DataVolumeStatus) DeepCopy() *DataVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRule) DeepCopyInto(out *ForwardingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRule.
+func (in *ForwardingRule) DeepCopy() *ForwardingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericInfo) DeepCopyInto(out *GenericInfo) {
+	*out = *in
+	if in.SSLRedirect != nil {
+		in, out := &in.SSLRedirect, &out.SSLRedirect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StickySessions != nil {
+		in, out := &in.StickySessions, &out.StickySessions
+		*out = new(StickySessions)
+		**out = **in
+	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericInfo.
+func (in *GenericInfo) DeepCopy() *GenericInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LBFirewallRule) DeepCopyInto(out *LBFirewallRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LBFirewallRule.
+func (in *LBFirewallRule) DeepCopy() *LBFirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LBFirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	in.APIServerLoadbalancers.DeepCopyInto(&out.APIServerLoadbalancers)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StickySessions) DeepCopyInto(out *StickySessions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StickySessions.
+func (in *StickySessions) DeepCopy() *StickySessions {
+	if in == nil {
+		return nil
+	}
+	out := new(StickySessions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrCluster) DeepCopyInto(out *VultrCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrCluster.
+func (in *VultrCluster) DeepCopy() *VultrCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterDeprecatedStatus) DeepCopyInto(out *VultrClusterDeprecatedStatus) {
+	*out = *in
+	if in.V1Beta1 != nil {
+		in, out := &in.V1Beta1, &out.V1Beta1
+		*out = new(VultrClusterV1Beta1DeprecatedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterDeprecatedStatus.
+func (in *VultrClusterDeprecatedStatus) DeepCopy() *VultrClusterDeprecatedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterDeprecatedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterInitializationStatus) DeepCopyInto(out *VultrClusterInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterInitializationStatus.
+func (in *VultrClusterInitializationStatus) DeepCopy() *VultrClusterInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterList) DeepCopyInto(out *VultrClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VultrCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterList.
+func (in *VultrClusterList) DeepCopy() *VultrClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterSpec) DeepCopyInto(out *VultrClusterSpec) {
+	*out = *in
+	in.Network.DeepCopyInto(&out.Network)
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterSpec.
+func (in *VultrClusterSpec) DeepCopy() *VultrClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterStatus) DeepCopyInto(out *VultrClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Initialization != nil {
+		in, out := &in.Initialization, &out.Initialization
+		*out = new(VultrClusterInitializationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Network = in.Network
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = new(VultrClusterDeprecatedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterStatus.
+func (in *VultrClusterStatus) DeepCopy() *VultrClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterTemplate) DeepCopyInto(out *VultrClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterTemplate.
+func (in *VultrClusterTemplate) DeepCopy() *VultrClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterTemplateList) DeepCopyInto(out *VultrClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VultrClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterTemplateList.
+func (in *VultrClusterTemplateList) DeepCopy() *VultrClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterTemplateResource) DeepCopyInto(out *VultrClusterTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterTemplateResource.
+func (in *VultrClusterTemplateResource) DeepCopy() *VultrClusterTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterTemplateSpec) DeepCopyInto(out *VultrClusterTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterTemplateSpec.
+func (in *VultrClusterTemplateSpec) DeepCopy() *VultrClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrClusterV1Beta1DeprecatedStatus) DeepCopyInto(out *VultrClusterV1Beta1DeprecatedStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.ClusterStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrClusterV1Beta1DeprecatedStatus.
+func (in *VultrClusterV1Beta1DeprecatedStatus) DeepCopy() *VultrClusterV1Beta1DeprecatedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrClusterV1Beta1DeprecatedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrLoadBalancer) DeepCopyInto(out *VultrLoadBalancer) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	if in.GenericInfo != nil {
+		in, out := &in.GenericInfo, &out.GenericInfo
+		*out = new(GenericInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLInfo != nil {
+		in, out := &in.SSLInfo, &out.SSLInfo
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForwardingRules != nil {
+		in, out := &in.ForwardingRules, &out.ForwardingRules
+		*out = make([]ForwardingRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.FirewallRules != nil {
+		in, out := &in.FirewallRules, &out.FirewallRules
+		*out = make([]LBFirewallRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrLoadBalancer.
+func (in *VultrLoadBalancer) DeepCopy() *VultrLoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrLoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachine) DeepCopyInto(out *VultrMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachine.
+func (in *VultrMachine) DeepCopy() *VultrMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineActionStatus) DeepCopyInto(out *VultrMachineActionStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineActionStatus.
+func (in *VultrMachineActionStatus) DeepCopy() *VultrMachineActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineDeprecatedStatus) DeepCopyInto(out *VultrMachineDeprecatedStatus) {
+	*out = *in
+	if in.V1Beta1 != nil {
+		in, out := &in.V1Beta1, &out.V1Beta1
+		*out = new(VultrMachineV1Beta1DeprecatedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineDeprecatedStatus.
+func (in *VultrMachineDeprecatedStatus) DeepCopy() *VultrMachineDeprecatedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineDeprecatedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineInitializationStatus) DeepCopyInto(out *VultrMachineInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineInitializationStatus.
+func (in *VultrMachineInitializationStatus) DeepCopy() *VultrMachineInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineList) DeepCopyInto(out *VultrMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VultrMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineList.
+func (in *VultrMachineList) DeepCopy() *VultrMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachinePlanUpgradeStatus) DeepCopyInto(out *VultrMachinePlanUpgradeStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachinePlanUpgradeStatus.
+func (in *VultrMachinePlanUpgradeStatus) DeepCopy() *VultrMachinePlanUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachinePlanUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineSpec) DeepCopyInto(out *VultrMachineSpec) {
+	*out = *in
+	if in.ProviderID != nil {
+		in, out := &in.ProviderID, &out.ProviderID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableIPv6 != nil {
+		in, out := &in.EnableIPv6, &out.EnableIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineSpec.
+func (in *VultrMachineSpec) DeepCopy() *VultrMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineStatus) DeepCopyInto(out *VultrMachineStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Initialization != nil {
+		in, out := &in.Initialization, &out.Initialization
+		*out = new(VultrMachineInitializationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]corev1.NodeAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubscriptionStatus != nil {
+		in, out := &in.SubscriptionStatus, &out.SubscriptionStatus
+		*out = new(SubscriptionStatus)
+		**out = **in
+	}
+	if in.PowerStatus != nil {
+		in, out := &in.PowerStatus, &out.PowerStatus
+		*out = new(PowerStatus)
+		**out = **in
+	}
+	if in.ServerState != nil {
+		in, out := &in.ServerState, &out.ServerState
+		*out = new(ServerState)
+		**out = **in
+	}
+	if in.LastAction != nil {
+		in, out := &in.LastAction, &out.LastAction
+		*out = new(VultrMachineActionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlanUpgrade != nil {
+		in, out := &in.PlanUpgrade, &out.PlanUpgrade
+		*out = new(VultrMachinePlanUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolumeStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedAdditionalTags != nil {
+		in, out := &in.AppliedAdditionalTags, &out.AppliedAdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = new(VultrMachineDeprecatedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineStatus.
+func (in *VultrMachineStatus) DeepCopy() *VultrMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplate) DeepCopyInto(out *VultrMachineTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplate.
+func (in *VultrMachineTemplate) DeepCopy() *VultrMachineTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrMachineTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplateList) DeepCopyInto(out *VultrMachineTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VultrMachineTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplateList.
+func (in *VultrMachineTemplateList) DeepCopy() *VultrMachineTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VultrMachineTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplateResource) DeepCopyInto(out *VultrMachineTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplateResource.
+func (in *VultrMachineTemplateResource) DeepCopy() *VultrMachineTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplateSpec) DeepCopyInto(out *VultrMachineTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplateSpec.
+func (in *VultrMachineTemplateSpec) DeepCopy() *VultrMachineTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineTemplateStatus) DeepCopyInto(out *VultrMachineTemplateStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineTemplateStatus.
+func (in *VultrMachineTemplateStatus) DeepCopy() *VultrMachineTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrMachineV1Beta1DeprecatedStatus) DeepCopyInto(out *VultrMachineV1Beta1DeprecatedStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachineStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrMachineV1Beta1DeprecatedStatus.
+func (in *VultrMachineV1Beta1DeprecatedStatus) DeepCopy() *VultrMachineV1Beta1DeprecatedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrMachineV1Beta1DeprecatedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrNetworkResource) DeepCopyInto(out *VultrNetworkResource) {
+	*out = *in
+	out.APIServerLoadbalancersRef = in.APIServerLoadbalancersRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrNetworkResource.
+func (in *VultrNetworkResource) DeepCopy() *VultrNetworkResource {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrNetworkResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VultrResourceReference) DeepCopyInto(out *VultrResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VultrResourceReference.
+func (in *VultrResourceReference) DeepCopy() *VultrResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VultrResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
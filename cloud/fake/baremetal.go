@@ -0,0 +1,256 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+type bareMetal struct {
+	govultr.BareMetalServer
+	userData string
+	readyAt  time.Time
+	vpcs     []govultr.VPCInfo
+	// n numbers the server's addresses.
+	n int
+}
+
+// refresh moves the bare metal server to active once its provisioning delay
+// passed.
+func (b *bareMetal) refresh() {
+	if b.readyAt.IsZero() || time.Now().Before(b.readyAt) {
+		return
+	}
+	b.Status = statusActive
+	b.readyAt = time.Time{}
+}
+
+// BareMetal returns the bare metal server with the given ID.
+func (s *Server) BareMetal(id string) (govultr.BareMetalServer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.bareMetals[id]
+	if !ok {
+		return govultr.BareMetalServer{}, false
+	}
+	b.refresh()
+	return b.BareMetalServer, true
+}
+
+// BareMetals returns all bare metal servers, ordered by ID.
+func (s *Server) BareMetals() []govultr.BareMetalServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	servers := make([]govultr.BareMetalServer, 0, len(s.bareMetals))
+	for _, b := range s.bareMetals {
+		b.refresh()
+		servers = append(servers, b.BareMetalServer)
+	}
+	sort.Slice(servers, func(a, b int) bool { return servers[a].ID < servers[b].ID })
+	return servers
+}
+
+// BareMetalUserData returns the decoded user data the bare metal server was
+// created with.
+func (s *Server) BareMetalUserData(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.bareMetals[id]
+	if !ok {
+		return "", false
+	}
+	return b.userData, true
+}
+
+func (s *Server) createBareMetal(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.BareMetalCreate{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Region == "" {
+		writeError(w, http.StatusBadRequest, "Invalid region.")
+		return
+	}
+	if !strings.HasPrefix(req.Plan, "vbm-") {
+		writeError(w, http.StatusBadRequest, "Invalid plan chosen.")
+		return
+	}
+	if s.soldOut[req.Region+"/"+req.Plan] {
+		writeError(w, http.StatusBadRequest, "Plan is not available in the selected region.")
+		return
+	}
+	if id := req.SnapshotID; id != "" {
+		if _, ok := s.snapshots[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid snapshot %s.", id))
+			return
+		}
+	}
+	for _, id := range req.SSHKeyIDs {
+		if _, ok := s.sshKeys[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid SSH key %s.", id))
+			return
+		}
+	}
+	userData, err := base64.StdEncoding.DecodeString(req.UserData)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid user data, must be base64 encoded.")
+		return
+	}
+
+	id := s.newID()
+	n := s.nextID
+	b := &bareMetal{
+		BareMetalServer: govultr.BareMetalServer{
+			ID:              id,
+			Region:          req.Region,
+			Plan:            req.Plan,
+			Label:           req.Label,
+			Tags:            req.Tags,
+			SnapshotID:      req.SnapshotID,
+			DateCreated:     time.Now().UTC().Format(time.RFC3339),
+			Status:          statusPending,
+			MainIP:          hostAddr(s.opts.PublicNetwork, n),
+			DefaultPassword: "fake-password",
+		},
+		userData: string(userData),
+		readyAt:  readyAt(s.opts.InstanceProvisionDelay, s.opts.InstanceProvisionJitter, s.opts.InstanceStuckRatio),
+		n:        n,
+	}
+	if req.EnableIPv6 != nil && *req.EnableIPv6 {
+		b.V6MainIP = fmt.Sprintf("2001:db8:1::%x", n)
+		b.V6Network = "2001:db8:1::"
+		b.V6NetworkSize = 64
+	}
+	b.refresh()
+	s.bareMetals[id] = b
+
+	writeJSON(w, http.StatusAccepted, map[string]any{"bare_metal": b.BareMetalServer})
+}
+
+func (s *Server) getBareMetal(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.bareMetals[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bare metal server not found.")
+		return
+	}
+	b.refresh()
+	resp := b.BareMetalServer
+	resp.DefaultPassword = ""
+	writeJSON(w, http.StatusOK, map[string]any{"bare_metal": resp})
+}
+
+func (s *Server) updateBareMetal(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.BareMetalUpdate{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.bareMetals[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bare metal server not found.")
+		return
+	}
+	b.refresh()
+	if req.Label != "" {
+		b.Label = req.Label
+	}
+	if req.Tags != nil {
+		b.Tags = req.Tags
+	}
+	resp := b.BareMetalServer
+	resp.DefaultPassword = ""
+	writeJSON(w, http.StatusAccepted, map[string]any{"bare_metal": resp})
+}
+
+func (s *Server) deleteBareMetal(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.bareMetals[id]; !ok {
+		writeError(w, http.StatusNotFound, "Bare metal server not found.")
+		return
+	}
+	delete(s.bareMetals, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listBareMetalVPCs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.bareMetals[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bare metal server not found.")
+		return
+	}
+	vpcs := append([]govultr.VPCInfo{}, b.vpcs...)
+	writeJSON(w, http.StatusOK, map[string]any{"vpcs": vpcs, "meta": listMeta(len(vpcs))})
+}
+
+func (s *Server) attachBareMetalVPC(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		VPCID string `json:"vpc_id"`
+	}{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	b, ok := s.bareMetals[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Bare metal server not found.")
+		return
+	}
+	if _, ok := s.vpcs[req.VPCID]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid VPC %s.", req.VPCID))
+		return
+	}
+	b.refresh()
+	if b.Status != statusActive {
+		writeError(w, http.StatusBadRequest, "Server is not active.")
+		return
+	}
+	for _, vpc := range b.vpcs {
+		if vpc.ID == req.VPCID {
+			writeError(w, http.StatusBadRequest, "Server is already attached to this VPC.")
+			return
+		}
+	}
+	b.vpcs = append(b.vpcs, govultr.VPCInfo{ID: req.VPCID, IPAddress: hostAddr(s.opts.PrivateNetwork, b.n)})
+	w.WriteHeader(http.StatusNoContent)
+}
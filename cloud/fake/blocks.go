@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// minBlockSizes are the minimum sizes in GB of the block storage types.
+var minBlockSizes = map[string]int{
+	"high_perf":   10,
+	"storage_opt": 40,
+}
+
+// Block returns the block storage with the given ID.
+func (s *Server) Block(id string) (govultr.BlockStorage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.blocks[id]
+	if !ok {
+		return govultr.BlockStorage{}, false
+	}
+	return *b, true
+}
+
+// Blocks returns all block storages, ordered by ID.
+func (s *Server) Blocks() []govultr.BlockStorage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedBlocks()
+}
+
+// sortedBlocks returns all block storages ordered by ID. The caller must hold
+// s.mu.
+func (s *Server) sortedBlocks() []govultr.BlockStorage {
+	blocks := make([]govultr.BlockStorage, 0, len(s.blocks))
+	for _, b := range s.blocks {
+		blocks = append(blocks, *b)
+	}
+	sort.Slice(blocks, func(a, b int) bool { return blocks[a].ID < blocks[b].ID })
+	return blocks
+}
+
+func (s *Server) listBlocks(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blocks := s.sortedBlocks()
+	writeJSON(w, http.StatusOK, map[string]any{"blocks": blocks, "meta": listMeta(len(blocks))})
+}
+
+func (s *Server) createBlock(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.BlockStorageCreate{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Region == "" {
+		writeError(w, http.StatusBadRequest, "Invalid region.")
+		return
+	}
+	if req.BlockType == "" {
+		req.BlockType = "high_perf"
+	}
+	minSize, ok := minBlockSizes[req.BlockType]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid block type %s.", req.BlockType))
+		return
+	}
+	if req.SizeGB < minSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid size, %s block storage must be at least %d GB.", req.BlockType, minSize))
+		return
+	}
+
+	id := s.newID()
+	b := &govultr.BlockStorage{
+		ID:          id,
+		Status:      statusActive,
+		SizeGB:      req.SizeGB,
+		Region:      req.Region,
+		DateCreated: time.Now().UTC().Format(time.RFC3339),
+		Label:       req.Label,
+		MountID:     req.Region + "-" + strings.ReplaceAll(id, "-", "")[16:],
+		BlockType:   req.BlockType,
+	}
+	s.blocks[id] = b
+	writeJSON(w, http.StatusAccepted, map[string]any{"block": b})
+}
+
+func (s *Server) getBlock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.blocks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Block storage not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"block": b})
+}
+
+func (s *Server) deleteBlock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	b, ok := s.blocks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Block storage not found.")
+		return
+	}
+	if b.AttachedToInstance != "" {
+		writeError(w, http.StatusBadRequest, "Block storage is attached to an instance, detach it first.")
+		return
+	}
+	delete(s.blocks, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) attachBlock(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.BlockStorageAttach{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.blocks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Block storage not found.")
+		return
+	}
+	i, ok := s.instances[req.InstanceID]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid instance %s.", req.InstanceID))
+		return
+	}
+	if i.Region != b.Region {
+		writeError(w, http.StatusBadRequest, "Block storage and instance must be in the same region.")
+		return
+	}
+	if b.AttachedToInstance != "" {
+		writeError(w, http.StatusBadRequest, "Block storage is already attached to an instance.")
+		return
+	}
+	b.AttachedToInstance = req.InstanceID
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) detachBlock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.blocks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Block storage not found.")
+		return
+	}
+	if b.AttachedToInstance == "" {
+		writeError(w, http.StatusBadRequest, "Block storage is not attached to an instance.")
+		return
+	}
+	b.AttachedToInstance = ""
+	w.WriteHeader(http.StatusNoContent)
+}
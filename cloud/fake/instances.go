@@ -0,0 +1,456 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	statusPending = "pending"
+	statusActive  = "active"
+
+	powerStatusRunning = "running"
+	powerStatusStopped = "stopped"
+
+	serverStatusNone       = "none"
+	serverStatusOK         = "ok"
+	serverStatusInstalling = "installingbooting"
+	serverStatusLocked     = "locked"
+)
+
+// plans are the plans instances can be upgraded between, from small to large.
+var plans = []string{
+	"vc2-1c-1gb",
+	"vc2-1c-2gb",
+	"vc2-2c-4gb",
+	"vc2-4c-8gb",
+	"vc2-6c-16gb",
+	"vc2-8c-32gb",
+	"vc2-16c-64gb",
+	"vc2-24c-96gb",
+}
+
+// planUpgrades returns the plans an instance with the given plan can be
+// upgraded to.
+func planUpgrades(plan string) []string {
+	i := slices.Index(plans, plan)
+	if i < 0 {
+		return []string{}
+	}
+	return slices.Clone(plans[i+1:])
+}
+
+type instance struct {
+	govultr.Instance
+	userData string
+	readyAt  time.Time
+	// upgradePlan is the plan the instance is being upgraded to.
+	upgradePlan string
+}
+
+// refresh moves the instance to active once its provisioning delay passed.
+func (i *instance) refresh() {
+	if i.readyAt.IsZero() || time.Now().Before(i.readyAt) {
+		return
+	}
+	if i.upgradePlan != "" {
+		i.Plan = i.upgradePlan
+		i.upgradePlan = ""
+	}
+	i.Status = statusActive
+	i.PowerStatus = powerStatusRunning
+	i.ServerStatus = serverStatusOK
+	i.readyAt = time.Time{}
+}
+
+// Instance returns the instance with the given ID.
+func (s *Server) Instance(id string) (govultr.Instance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.instances[id]
+	if !ok {
+		return govultr.Instance{}, false
+	}
+	i.refresh()
+	return i.Instance, true
+}
+
+// Instances returns all instances, ordered by ID.
+func (s *Server) Instances() []govultr.Instance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedInstances()
+}
+
+// InstanceUserData returns the decoded user data the instance was created
+// or last updated with.
+func (s *Server) InstanceUserData(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.instances[id]
+	if !ok {
+		return "", false
+	}
+	return i.userData, true
+}
+
+// SetInstanceStatus overrides the subscription and power status of an
+// instance, e.g. to simulate a suspended or halted instance. The instance
+// no longer becomes active on its own afterwards.
+func (s *Server) SetInstanceStatus(id, status, powerStatus string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.instances[id]
+	if !ok {
+		return false
+	}
+	i.Status = status
+	i.PowerStatus = powerStatus
+	i.readyAt = time.Time{}
+	return true
+}
+
+// RemoveInstance deletes an instance behind the provider's back.
+func (s *Server) RemoveInstance(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.instances[id]; !ok {
+		return false
+	}
+	s.removeInstance(id)
+	return true
+}
+
+// removeInstance deletes an instance and detaches it from all load
+// balancers and block storages. The caller must hold s.mu.
+func (s *Server) removeInstance(id string) {
+	delete(s.instances, id)
+	for _, lb := range s.loadBalancers {
+		lb.Instances = slices.DeleteFunc(lb.Instances, func(i string) bool { return i == id })
+	}
+	for _, b := range s.blocks {
+		if b.AttachedToInstance == id {
+			b.AttachedToInstance = ""
+		}
+	}
+}
+
+// sortedInstances returns all instances ordered by ID. The caller must hold
+// s.mu.
+func (s *Server) sortedInstances() []govultr.Instance {
+	instances := make([]govultr.Instance, 0, len(s.instances))
+	for _, i := range s.instances {
+		i.refresh()
+		instances = append(instances, i.Instance)
+	}
+	sort.Slice(instances, func(a, b int) bool { return instances[a].ID < instances[b].ID })
+	return instances
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	instances := []govultr.Instance{}
+	for _, i := range s.sortedInstances() {
+		if tag := q.Get("tag"); tag != "" && !slices.Contains(i.Tags, tag) {
+			continue
+		}
+		if label := q.Get("label"); label != "" && i.Label != label {
+			continue
+		}
+		if region := q.Get("region"); region != "" && i.Region != region {
+			continue
+		}
+		if mainIP := q.Get("main_ip"); mainIP != "" && i.MainIP != mainIP {
+			continue
+		}
+		instances = append(instances, i)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"instances": instances, "meta": listMeta(len(instances))})
+}
+
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.InstanceCreateReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Region == "" {
+		writeError(w, http.StatusBadRequest, "Invalid region.")
+		return
+	}
+	if req.Plan == "" {
+		writeError(w, http.StatusBadRequest, "Invalid plan chosen.")
+		return
+	}
+	if s.soldOut[req.Region+"/"+req.Plan] {
+		writeError(w, http.StatusBadRequest, "Plan is not available in the selected region.")
+		return
+	}
+	if id := req.SnapshotID; id != "" {
+		if _, ok := s.snapshots[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid snapshot %s.", id))
+			return
+		}
+	}
+	for _, id := range req.SSHKeys {
+		if _, ok := s.sshKeys[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid SSH key %s.", id))
+			return
+		}
+	}
+	if id := req.FirewallGroupID; id != "" {
+		if _, ok := s.firewallGroups[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid firewall group %s.", id))
+			return
+		}
+	}
+	for _, id := range req.AttachVPC {
+		if _, ok := s.vpcs[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid VPC %s.", id))
+			return
+		}
+	}
+	userData, err := base64.StdEncoding.DecodeString(req.UserData)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid user data, must be base64 encoded.")
+		return
+	}
+
+	id := s.newID()
+	n := s.nextID
+	i := &instance{
+		Instance: govultr.Instance{
+			ID:              id,
+			Region:          req.Region,
+			Plan:            req.Plan,
+			Label:           req.Label,
+			Hostname:        req.Hostname,
+			Tags:            req.Tags,
+			SnapshotID:      req.SnapshotID,
+			FirewallGroupID: req.FirewallGroupID,
+			DateCreated:     time.Now().UTC().Format(time.RFC3339),
+			Status:          statusPending,
+			PowerStatus:     powerStatusStopped,
+			ServerStatus:    serverStatusNone,
+			DefaultPassword: "fake-password",
+		},
+		userData: string(userData),
+		readyAt:  readyAt(s.opts.InstanceProvisionDelay, s.opts.InstanceProvisionJitter, s.opts.InstanceStuckRatio),
+	}
+	public := req.VPCOnly == nil || !*req.VPCOnly
+	if public {
+		i.MainIP = hostAddr(s.opts.PublicNetwork, n)
+	}
+	if len(req.AttachVPC) > 0 {
+		i.InternalIP = hostAddr(s.opts.PrivateNetwork, n)
+	}
+	if public && req.EnableIPv6 != nil && *req.EnableIPv6 {
+		i.V6MainIP = fmt.Sprintf("2001:db8::%x", n)
+		i.V6Network = "2001:db8::"
+		i.V6NetworkSize = 64
+	}
+	i.refresh()
+	s.instances[id] = i
+
+	resp := i.Instance
+	writeJSON(w, http.StatusAccepted, map[string]any{"instance": resp})
+}
+
+func (s *Server) getInstance(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	i.refresh()
+	resp := i.Instance
+	resp.DefaultPassword = ""
+	writeJSON(w, http.StatusOK, map[string]any{"instance": resp})
+}
+
+func (s *Server) updateInstance(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.InstanceUpdateReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	if id := req.FirewallGroupID; id != "" {
+		if _, ok := s.firewallGroups[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid firewall group %s.", id))
+			return
+		}
+	}
+	var userData []byte
+	if req.UserData != "" {
+		var err error
+		if userData, err = base64.StdEncoding.DecodeString(req.UserData); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid user data, must be base64 encoded.")
+			return
+		}
+	}
+	i.refresh()
+	if req.Plan != "" && req.Plan != i.Plan {
+		if !slices.Contains(planUpgrades(i.Plan), req.Plan) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid plan %s. Plans can only be upgraded.", req.Plan))
+			return
+		}
+		// The instance is resized while it is locked.
+		i.upgradePlan = req.Plan
+		i.Status = statusPending
+		i.ServerStatus = serverStatusLocked
+		i.readyAt = readyAt(s.opts.InstanceProvisionDelay, s.opts.InstanceProvisionJitter, 0)
+		i.refresh()
+	}
+	if req.Label != "" {
+		i.Label = req.Label
+	}
+	if req.Tags != nil {
+		i.Tags = req.Tags
+	}
+	if req.FirewallGroupID != "" {
+		i.FirewallGroupID = req.FirewallGroupID
+	}
+	if userData != nil {
+		i.userData = string(userData)
+	}
+	resp := i.Instance
+	resp.DefaultPassword = ""
+	writeJSON(w, http.StatusAccepted, map[string]any{"instance": resp})
+}
+
+func (s *Server) deleteInstance(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.instances[id]; !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	s.removeInstance(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) powerInstance(powerStatus string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i, ok := s.instances[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Instance not found.")
+			return
+		}
+		i.refresh()
+		i.PowerStatus = powerStatus
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) reinstallInstance(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.ReinstallReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	if req.Hostname != "" {
+		i.Hostname = req.Hostname
+	}
+	// The instance is provisioned again, keeping its image and user data.
+	i.Status = statusPending
+	i.PowerStatus = powerStatusStopped
+	i.ServerStatus = serverStatusInstalling
+	i.readyAt = readyAt(s.opts.InstanceProvisionDelay, s.opts.InstanceProvisionJitter, 0)
+	i.refresh()
+	resp := i.Instance
+	resp.DefaultPassword = ""
+	writeJSON(w, http.StatusAccepted, map[string]any{"instance": resp})
+}
+
+func (s *Server) restoreInstance(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.RestoreReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	if _, ok := s.snapshots[req.SnapshotID]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid snapshot %s.", req.SnapshotID))
+		return
+	}
+	// The instance is provisioned again from the snapshot.
+	i.SnapshotID = req.SnapshotID
+	i.Status = statusPending
+	i.PowerStatus = powerStatusStopped
+	i.ServerStatus = serverStatusInstalling
+	i.readyAt = readyAt(s.opts.InstanceProvisionDelay, s.opts.InstanceProvisionJitter, 0)
+	i.refresh()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) getInstanceUpgrades(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Instance not found.")
+		return
+	}
+	i.refresh()
+	writeJSON(w, http.StatusOK, map[string]any{"upgrades": govultr.Upgrades{Plans: planUpgrades(i.Plan)}})
+}
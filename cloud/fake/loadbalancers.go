@@ -0,0 +1,227 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+type loadBalancer struct {
+	govultr.LoadBalancer
+	readyAt time.Time
+}
+
+// refresh moves the load balancer to active once its provisioning delay
+// passed.
+func (lb *loadBalancer) refresh() {
+	if lb.readyAt.IsZero() || time.Now().Before(lb.readyAt) {
+		return
+	}
+	lb.Status = statusActive
+	lb.readyAt = time.Time{}
+}
+
+// LoadBalancer returns the load balancer with the given ID.
+func (s *Server) LoadBalancer(id string) (govultr.LoadBalancer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, ok := s.loadBalancers[id]
+	if !ok {
+		return govultr.LoadBalancer{}, false
+	}
+	lb.refresh()
+	return lb.LoadBalancer, true
+}
+
+// LoadBalancers returns all load balancers, ordered by ID.
+func (s *Server) LoadBalancers() []govultr.LoadBalancer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedLoadBalancers()
+}
+
+// SetLoadBalancerStatus overrides the status of a load balancer. The load
+// balancer no longer becomes active on its own afterwards.
+func (s *Server) SetLoadBalancerStatus(id, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, ok := s.loadBalancers[id]
+	if !ok {
+		return false
+	}
+	lb.Status = status
+	lb.readyAt = time.Time{}
+	return true
+}
+
+// RemoveLoadBalancer deletes a load balancer behind the provider's back.
+func (s *Server) RemoveLoadBalancer(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.loadBalancers[id]; !ok {
+		return false
+	}
+	delete(s.loadBalancers, id)
+	return true
+}
+
+// sortedLoadBalancers returns all load balancers ordered by ID. The caller
+// must hold s.mu.
+func (s *Server) sortedLoadBalancers() []govultr.LoadBalancer {
+	lbs := make([]govultr.LoadBalancer, 0, len(s.loadBalancers))
+	for _, lb := range s.loadBalancers {
+		lb.refresh()
+		lbs = append(lbs, lb.LoadBalancer)
+	}
+	sort.Slice(lbs, func(a, b int) bool { return lbs[a].ID < lbs[b].ID })
+	return lbs
+}
+
+func (s *Server) listLoadBalancers(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lbs := s.sortedLoadBalancers()
+	writeJSON(w, http.StatusOK, map[string]any{"load_balancers": lbs, "meta": listMeta(len(lbs))})
+}
+
+func (s *Server) createLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.LoadBalancerReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Region == "" {
+		writeError(w, http.StatusBadRequest, "Invalid region.")
+		return
+	}
+	if req.VPC != nil && *req.VPC != "" {
+		if _, ok := s.vpcs[*req.VPC]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid VPC %s.", *req.VPC))
+			return
+		}
+	}
+	if err := s.validateInstances(req.Instances); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := s.newID()
+	lb := &loadBalancer{
+		LoadBalancer: govultr.LoadBalancer{
+			ID:              id,
+			DateCreated:     time.Now().UTC().Format(time.RFC3339),
+			Region:          req.Region,
+			Label:           req.Label,
+			Status:          statusPending,
+			IPV4:            fmt.Sprintf("198.51.100.%d", s.nextID%254+1),
+			Instances:       req.Instances,
+			Nodes:           max(req.Nodes, 1),
+			HealthCheck:     req.HealthCheck,
+			ForwardingRules: req.ForwardingRules,
+			FirewallRules:   req.FirewallRules,
+			GenericInfo:     &govultr.GenericInfo{BalancingAlgorithm: req.BalancingAlgorithm},
+		},
+		readyAt: readyAt(s.opts.LoadBalancerProvisionDelay, s.opts.LoadBalancerProvisionJitter, s.opts.LoadBalancerStuckRatio),
+	}
+	lb.refresh()
+	s.loadBalancers[id] = lb
+
+	writeJSON(w, http.StatusAccepted, map[string]any{"load_balancer": lb.LoadBalancer})
+}
+
+func (s *Server) getLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lb, ok := s.loadBalancers[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Load balancer not found.")
+		return
+	}
+	lb.refresh()
+	writeJSON(w, http.StatusOK, map[string]any{"load_balancer": lb.LoadBalancer})
+}
+
+func (s *Server) updateLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	req := &govultr.LoadBalancerReq{}
+	if !readJSON(w, r, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lb, ok := s.loadBalancers[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Load balancer not found.")
+		return
+	}
+	if err := s.validateInstances(req.Instances); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	lb.refresh()
+	if req.Label != "" {
+		lb.Label = req.Label
+	}
+	if req.Instances != nil {
+		lb.Instances = req.Instances
+	}
+	if req.HealthCheck != nil {
+		lb.HealthCheck = req.HealthCheck
+	}
+	if req.ForwardingRules != nil {
+		lb.ForwardingRules = req.ForwardingRules
+	}
+	if req.FirewallRules != nil {
+		lb.FirewallRules = req.FirewallRules
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.loadBalancers[id]; !ok {
+		writeError(w, http.StatusNotFound, "Load balancer not found.")
+		return
+	}
+	delete(s.loadBalancers, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateInstances checks that all instances exist. The caller must hold
+// s.mu.
+func (s *Server) validateInstances(ids []string) error {
+	for _, id := range ids {
+		if _, ok := s.instances[id]; !ok {
+			return fmt.Errorf("Invalid instance %s.", id) //nolint:staticcheck
+		}
+	}
+	return nil
+}
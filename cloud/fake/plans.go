@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// regions are the regions all plans are offered in, unless marked
+// unavailable.
+var regions = []string{
+	"ams", "atl", "blr", "bom", "cdg", "del", "dfw", "ewr", "fra", "hnl", "icn",
+	"itm", "jnb", "lax", "lhr", "mad", "man", "mel", "mex", "mia", "nrt", "ord",
+	"sao", "scl", "sea", "sgp", "sjc", "sto", "syd", "tlv", "waw", "yto",
+}
+
+// instancePlans is the plan catalog of instances.
+var instancePlans = []govultr.Plan{
+	{ID: "vc2-1c-1gb", VCPUCount: 1, RAM: 1024, Disk: 25, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-1c-2gb", VCPUCount: 1, RAM: 2048, Disk: 55, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-2c-4gb", VCPUCount: 2, RAM: 4096, Disk: 80, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-4c-8gb", VCPUCount: 4, RAM: 8192, Disk: 160, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-6c-16gb", VCPUCount: 6, RAM: 16384, Disk: 320, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-8c-32gb", VCPUCount: 8, RAM: 32768, Disk: 640, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-16c-64gb", VCPUCount: 16, RAM: 65536, Disk: 1280, DiskCount: 1, Type: "vc2"},
+	{ID: "vc2-24c-96gb", VCPUCount: 24, RAM: 98304, Disk: 1600, DiskCount: 1, Type: "vc2"},
+	{ID: "vhf-1c-1gb", VCPUCount: 1, RAM: 1024, Disk: 32, DiskCount: 1, Type: "vhf"},
+	{ID: "vhf-2c-4gb", VCPUCount: 2, RAM: 4096, Disk: 128, DiskCount: 1, Type: "vhf"},
+	{ID: "vhf-4c-16gb", VCPUCount: 4, RAM: 16384, Disk: 384, DiskCount: 1, Type: "vhf"},
+	{ID: "vhp-2c-4gb-amd", VCPUCount: 2, RAM: 4096, Disk: 100, DiskCount: 1, Type: "vhp"},
+	{ID: "vhp-4c-8gb-amd", VCPUCount: 4, RAM: 8192, Disk: 180, DiskCount: 1, Type: "vhp"},
+	{ID: "vcg-a16-6c-64g-16vram", VCPUCount: 6, RAM: 65536, Disk: 350, DiskCount: 1, Type: "vcg", GPUVRAM: 16, GPUType: "NVIDIA_A16"},
+}
+
+// bareMetalPlans is the plan catalog of bare metal servers.
+var bareMetalPlans = []govultr.BareMetalPlan{
+	{ID: "vbm-4c-32gb", CPUCount: 4, CPUThreads: 8, CPUModel: "E3-1270v6", RAM: 32768, Disk: 240, DiskCount: 2, Type: "SSD"},
+	{ID: "vbm-8c-132gb", CPUCount: 8, CPUThreads: 16, CPUModel: "E-2288G", RAM: 131072, Disk: 1920, DiskCount: 2, Type: "NVMe"},
+}
+
+func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
+	planType := r.URL.Query().Get("type")
+	plans := []govultr.Plan{}
+	for _, plan := range instancePlans {
+		if planType == "" || planType == "all" || plan.Type == planType {
+			plans = append(plans, plan)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"plans": plans, "meta": listMeta(len(plans))})
+}
+
+func (s *Server) listBareMetalPlans(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"plans_metal": bareMetalPlans, "meta": listMeta(len(bareMetalPlans))})
+}
+
+// SetPlanAvailable makes a plan available in a region again, or sold out
+// there.
+func (s *Server) SetPlanAvailable(region, plan string, available bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := region + "/" + plan
+	if available {
+		delete(s.soldOut, key)
+	} else {
+		s.soldOut[key] = true
+	}
+}
+
+func (s *Server) getRegionAvailability(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	region := r.PathValue("id")
+	if !slices.Contains(regions, region) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Region %s not found.", region))
+		return
+	}
+	planType := r.URL.Query().Get("type")
+	available := []string{}
+	for _, plan := range instancePlans {
+		if (planType == "" || planType == "all" || plan.Type == planType) && !s.soldOut[region+"/"+plan.ID] {
+			available = append(available, plan.ID)
+		}
+	}
+	for _, plan := range bareMetalPlans {
+		if (planType == "" || planType == "all" || planType == "vbm") && !s.soldOut[region+"/"+plan.ID] {
+			available = append(available, plan.ID)
+		}
+	}
+	writeJSON(w, http.StatusOK, govultr.PlanAvailability{AvailablePlans: available})
+}
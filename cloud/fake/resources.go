@@ -0,0 +1,69 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/http"
+)
+
+func (s *Server) getVPC(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vpc, ok := s.vpcs[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "VPC not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"vpc": vpc})
+}
+
+func (s *Server) getFirewallGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.firewallGroups[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Firewall group not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"firewall_group": group})
+}
+
+func (s *Server) getSSHKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.sshKeys[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "SSH key not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ssh_key": key})
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot, ok := s.snapshots[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Snapshot not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"snapshot": snapshot})
+}
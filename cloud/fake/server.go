@@ -0,0 +1,467 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake implements an in-memory Vultr API server for tests and the
+// simulator.
+//
+// The server speaks the subset of the Vultr v2 REST API used by the provider,
+// so the real govultr client, transports and error handling are exercised.
+// It keeps instance, bare metal server, load balancer and block storage
+// state, moves resources from pending to active after a configurable delay,
+// and can inject faults such as rate limiting, server errors or slow
+// responses. Bare metal servers are provisioned like instances, plans are
+// served from a static catalog.
+package fake
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"golang.org/x/time/rate"
+)
+
+// Never can be used as a provisioning delay to keep resources pending forever.
+const Never time.Duration = -1
+
+// Options configures a Server.
+type Options struct {
+	// APIKey is the key clients must send as bearer token. Any token is
+	// accepted when empty.
+	APIKey string
+	// InstanceProvisionDelay is how long instances stay pending after they
+	// were created. Never keeps them pending.
+	InstanceProvisionDelay time.Duration
+	// LoadBalancerProvisionDelay is how long load balancers stay pending
+	// after they were created. Never keeps them pending.
+	LoadBalancerProvisionDelay time.Duration
+	// InstanceProvisionJitter adds a random duration of up to it to the
+	// provisioning delay of instances.
+	InstanceProvisionJitter time.Duration
+	// LoadBalancerProvisionJitter adds a random duration of up to it to the
+	// provisioning delay of load balancers.
+	LoadBalancerProvisionJitter time.Duration
+	// InstanceStuckRatio is the fraction of instances, between 0 and 1, which
+	// stay pending forever.
+	InstanceStuckRatio float64
+	// LoadBalancerStuckRatio is the fraction of load balancers, between 0
+	// and 1, which stay pending forever.
+	LoadBalancerStuckRatio float64
+	// Latency is waited before every request is handled.
+	Latency time.Duration
+	// RateLimit is the number of requests per second handled before requests
+	// are rejected with 429 Too Many Requests, like the Vultr API does.
+	// Requests are not limited when zero.
+	RateLimit float64
+	// RateLimitBurst is the number of requests handled in a single burst. It
+	// defaults to one when RateLimit is set.
+	RateLimitBurst int
+	// PublicNetwork is the IPv4 network main IPs of instances are allocated
+	// from. It defaults to 192.0.2.0/24.
+	PublicNetwork netip.Prefix
+	// PrivateNetwork is the IPv4 network internal IPs of instances attached
+	// to a VPC are allocated from. It defaults to 10.1.96.0/20.
+	PrivateNetwork netip.Prefix
+}
+
+var (
+	defaultPublicNetwork  = netip.MustParsePrefix("192.0.2.0/24")
+	defaultPrivateNetwork = netip.MustParsePrefix("10.1.96.0/20")
+)
+
+// Fault makes matching requests fail or respond slowly.
+type Fault struct {
+	// Method matches the HTTP method of a request, any method if empty.
+	Method string
+	// Path matches requests whose path starts with it, e.g.
+	// "/v2/instances". Any path if empty.
+	Path string
+	// StatusCode is sent instead of the regular response. Zero only applies
+	// Delay.
+	StatusCode int
+	// Message is the error message sent along with StatusCode.
+	Message string
+	// Delay is waited before the request is handled.
+	Delay time.Duration
+	// Times is the number of matching requests the fault applies to. Zero
+	// applies it to every matching request.
+	Times int
+	// Probability is the chance, between 0 and 1, that the fault applies to a
+	// matching request. The fault always applies when zero.
+	Probability float64
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Server is an in-memory Vultr API server.
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	opts           Options
+	limiter        *rate.Limiter
+	nextID         int
+	faults         []*Fault
+	requests       []Request
+	instances      map[string]*instance
+	loadBalancers  map[string]*loadBalancer
+	blocks         map[string]*govultr.BlockStorage
+	bareMetals     map[string]*bareMetal
+	vpcs           map[string]*govultr.VPC
+	firewallGroups map[string]*govultr.FirewallGroup
+	sshKeys        map[string]*govultr.SSHKey
+	snapshots      map[string]*govultr.Snapshot
+	// soldOut holds the plans which are not available, keyed by
+	// "<region>/<plan>".
+	soldOut map[string]bool
+}
+
+// NewServer starts a Server. It must be closed once it is no longer used.
+func NewServer(opts Options) *Server {
+	if !opts.PublicNetwork.IsValid() {
+		opts.PublicNetwork = defaultPublicNetwork
+	}
+	if !opts.PrivateNetwork.IsValid() {
+		opts.PrivateNetwork = defaultPrivateNetwork
+	}
+	s := &Server{
+		opts:           opts,
+		instances:      map[string]*instance{},
+		loadBalancers:  map[string]*loadBalancer{},
+		blocks:         map[string]*govultr.BlockStorage{},
+		bareMetals:     map[string]*bareMetal{},
+		vpcs:           map[string]*govultr.VPC{},
+		firewallGroups: map[string]*govultr.FirewallGroup{},
+		sshKeys:        map[string]*govultr.SSHKey{},
+		snapshots:      map[string]*govultr.Snapshot{},
+		soldOut:        map[string]bool{},
+	}
+	if opts.RateLimit > 0 {
+		s.limiter = rate.NewLimiter(rate.Limit(opts.RateLimit), max(opts.RateLimitBurst, 1))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/instances", s.listInstances)
+	mux.HandleFunc("POST /v2/instances", s.createInstance)
+	mux.HandleFunc("GET /v2/instances/{id}", s.getInstance)
+	mux.HandleFunc("PATCH /v2/instances/{id}", s.updateInstance)
+	mux.HandleFunc("DELETE /v2/instances/{id}", s.deleteInstance)
+	mux.HandleFunc("POST /v2/instances/{id}/start", s.powerInstance(powerStatusRunning))
+	mux.HandleFunc("POST /v2/instances/{id}/halt", s.powerInstance(powerStatusStopped))
+	mux.HandleFunc("POST /v2/instances/{id}/reboot", s.powerInstance(powerStatusRunning))
+	mux.HandleFunc("POST /v2/instances/{id}/reinstall", s.reinstallInstance)
+	mux.HandleFunc("POST /v2/instances/{id}/restore", s.restoreInstance)
+	mux.HandleFunc("GET /v2/instances/{id}/upgrades", s.getInstanceUpgrades)
+	mux.HandleFunc("POST /v2/bare-metals", s.createBareMetal)
+	mux.HandleFunc("GET /v2/bare-metals/{id}", s.getBareMetal)
+	mux.HandleFunc("PATCH /v2/bare-metals/{id}", s.updateBareMetal)
+	mux.HandleFunc("DELETE /v2/bare-metals/{id}", s.deleteBareMetal)
+	mux.HandleFunc("GET /v2/bare-metals/{id}/vpcs", s.listBareMetalVPCs)
+	mux.HandleFunc("POST /v2/bare-metals/{id}/vpcs/attach", s.attachBareMetalVPC)
+	mux.HandleFunc("GET /v2/load-balancers", s.listLoadBalancers)
+	mux.HandleFunc("POST /v2/load-balancers", s.createLoadBalancer)
+	mux.HandleFunc("GET /v2/load-balancers/{id}", s.getLoadBalancer)
+	mux.HandleFunc("PATCH /v2/load-balancers/{id}", s.updateLoadBalancer)
+	mux.HandleFunc("DELETE /v2/load-balancers/{id}", s.deleteLoadBalancer)
+	mux.HandleFunc("GET /v2/blocks", s.listBlocks)
+	mux.HandleFunc("POST /v2/blocks", s.createBlock)
+	mux.HandleFunc("GET /v2/blocks/{id}", s.getBlock)
+	mux.HandleFunc("DELETE /v2/blocks/{id}", s.deleteBlock)
+	mux.HandleFunc("POST /v2/blocks/{id}/attach", s.attachBlock)
+	mux.HandleFunc("POST /v2/blocks/{id}/detach", s.detachBlock)
+	mux.HandleFunc("GET /v2/plans", s.listPlans)
+	mux.HandleFunc("GET /v2/plans-metal", s.listBareMetalPlans)
+	mux.HandleFunc("GET /v2/regions/{id}/availability", s.getRegionAvailability)
+	mux.HandleFunc("GET /v2/snapshots/{id}", s.getSnapshot)
+	mux.HandleFunc("GET /v2/vpcs/{id}", s.getVPC)
+	mux.HandleFunc("GET /v2/firewalls/{id}", s.getFirewallGroup)
+	mux.HandleFunc("GET /v2/ssh-keys/{id}", s.getSSHKey)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// VultrClient returns a govultr client talking to the server.
+func (s *Server) VultrClient() *govultr.Client {
+	s.mu.Lock()
+	key := s.opts.APIKey
+	s.mu.Unlock()
+	if key == "" {
+		key = "fake"
+	}
+	c := govultr.NewClient(&http.Client{Transport: &bearerTransport{token: key}})
+	_ = c.SetBaseURL(s.URL)
+	c.SetRateLimit(10 * time.Millisecond)
+	return c
+}
+
+type bearerTransport struct {
+	token string
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// InjectFault adds f to the faults applied to incoming requests. Faults are
+// matched in the order they were added.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetAPIKey changes the key clients must send, e.g. to simulate the rotation
+// of a key. An empty key accepts any token.
+func (s *Server) SetAPIKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts.APIKey = key
+}
+
+// SetInstanceProvisionDelay changes the provisioning delay of instances
+// created from now on.
+func (s *Server) SetInstanceProvisionDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts.InstanceProvisionDelay = d
+}
+
+// SetLoadBalancerProvisionDelay changes the provisioning delay of load
+// balancers created from now on.
+func (s *Server) SetLoadBalancerProvisionDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts.LoadBalancerProvisionDelay = d
+}
+
+// Requests returns the number of received requests matching method and path
+// prefix. Empty values match every request.
+func (s *Server) Requests(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if (method == "" || r.Method == method) && strings.HasPrefix(r.Path, path) {
+			n++
+		}
+	}
+	return n
+}
+
+// AddVPC adds a VPC and returns its ID.
+func (s *Server) AddVPC(vpc govultr.VPC) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if vpc.ID == "" {
+		vpc.ID = s.newID()
+	}
+	s.vpcs[vpc.ID] = &vpc
+	return vpc.ID
+}
+
+// AddFirewallGroup adds a firewall group and returns its ID.
+func (s *Server) AddFirewallGroup(group govultr.FirewallGroup) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if group.ID == "" {
+		group.ID = s.newID()
+	}
+	s.firewallGroups[group.ID] = &group
+	return group.ID
+}
+
+// AddSSHKey adds an SSH key and returns its ID.
+func (s *Server) AddSSHKey(key govultr.SSHKey) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.ID == "" {
+		key.ID = s.newID()
+	}
+	s.sshKeys[key.ID] = &key
+	return key.ID
+}
+
+// AddSnapshot adds a snapshot and returns its ID. Its status defaults to
+// complete.
+func (s *Server) AddSnapshot(snapshot govultr.Snapshot) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if snapshot.ID == "" {
+		snapshot.ID = s.newID()
+	}
+	if snapshot.Status == "" {
+		snapshot.Status = "complete"
+	}
+	s.snapshots[snapshot.ID] = &snapshot
+	return snapshot.ID
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+		fault := s.matchFault(r)
+		apiKey := s.opts.APIKey
+		s.mu.Unlock()
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") || (apiKey != "" && auth != "Bearer "+apiKey) {
+			writeError(w, http.StatusUnauthorized, "Invalid API token.")
+			return
+		}
+
+		if s.opts.Latency > 0 {
+			select {
+			case <-time.After(s.opts.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if s.limiter != nil && !s.limiter.Allow() {
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusTooManyRequests, "Rate limit reached - please try your request again later.")
+			return
+		}
+
+		if fault != nil {
+			if fault.Delay > 0 {
+				select {
+				case <-time.After(fault.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fault.StatusCode != 0 {
+				if fault.StatusCode == http.StatusTooManyRequests {
+					// Let the client retry right away instead of backing off.
+					w.Header().Set("Retry-After", "0")
+				}
+				msg := fault.Message
+				if msg == "" {
+					msg = http.StatusText(fault.StatusCode)
+				}
+				writeError(w, fault.StatusCode, msg)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault returns the first fault matching r and consumes it. The caller
+// must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Probability > 0 && rand.Float64() >= f.Probability {
+			continue
+		}
+		match := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return &match
+	}
+	return nil
+}
+
+// newID returns a new resource ID. The caller must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+}
+
+// readyAt returns when a resource created now with the provisioning delay d
+// plus up to jitter becomes active, or the zero time if it never does.
+// stuckRatio is the chance that it never becomes active.
+func readyAt(d, jitter time.Duration, stuckRatio float64) time.Time {
+	if d < 0 || (stuckRatio > 0 && rand.Float64() < stuckRatio) {
+		return time.Time{}
+	}
+	if jitter > 0 {
+		d += rand.N(jitter)
+	}
+	return time.Now().Add(d)
+}
+
+// hostAddr returns the nth host address of the IPv4 network p. It wraps
+// around once all addresses were used.
+func hostAddr(p netip.Prefix, n int) string {
+	base := p.Masked().Addr().As4()
+	hosts := uint32(1)<<(32-p.Bits()) - 2
+	if p.Bits() >= 31 {
+		hosts = 1
+	}
+	ip := binary.BigEndian.Uint32(base[:]) + uint32(n)%hosts + 1
+	binary.BigEndian.PutUint32(base[:], ip)
+	return netip.AddrFrom4(base).String()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]any{"error": msg, "status": status})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func listMeta(total int) *govultr.Meta {
+	return &govultr.Meta{Total: total, Links: &govultr.Links{}}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/netip"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+)
+
+func TestInstanceLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{InstanceProvisionDelay: 50 * time.Millisecond})
+	defer s.Close()
+	c := s.VultrClient()
+
+	vpcID := s.AddVPC(govultr.VPC{Region: "ewr"})
+	created, _, err := c.Instance.Create(ctx, &govultr.InstanceCreateReq{
+		Region:     "ewr",
+		Plan:       "vc2-2c-4gb",
+		Label:      "node",
+		Tags:       []string{"cluster"},
+		AttachVPC:  []string{vpcID},
+		EnableIPv6: govultr.BoolToBoolPtr(true),
+		UserData:   base64.StdEncoding.EncodeToString([]byte("#cloud-config")),
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created.Status).To(Equal("pending"))
+	g.Expect(created.MainIP).NotTo(BeEmpty())
+	g.Expect(created.InternalIP).NotTo(BeEmpty())
+	g.Expect(created.V6MainIP).NotTo(BeEmpty())
+	userData, ok := s.InstanceUserData(created.ID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(userData).To(Equal("#cloud-config"))
+
+	g.Eventually(func() string {
+		i, _, err := c.Instance.Get(ctx, created.ID)
+		g.Expect(err).NotTo(HaveOccurred())
+		return i.Status + "/" + i.PowerStatus
+	}).Should(Equal("active/running"))
+
+	g.Expect(c.Instance.Halt(ctx, created.ID)).To(Succeed())
+	i, _ := s.Instance(created.ID)
+	g.Expect(i.PowerStatus).To(Equal("stopped"))
+
+	instances, _, _, err := c.Instance.List(ctx, &govultr.ListOptions{Tag: "cluster"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instances).To(HaveLen(1))
+
+	g.Expect(c.Instance.Delete(ctx, created.ID)).To(Succeed())
+	_, resp, err := c.Instance.Get(ctx, created.ID)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
+
+func TestCreateInstanceValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewServer(Options{})
+	defer s.Close()
+
+	_, resp, err := s.VultrClient().Instance.Create(context.Background(), &govultr.InstanceCreateReq{
+		Region:  "ewr",
+		Plan:    "vc2-2c-4gb",
+		SSHKeys: []string{"missing"},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("Invalid SSH key")))
+	g.Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	g.Expect(s.Instances()).To(BeEmpty())
+}
+
+func TestLoadBalancerStuckPending(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{LoadBalancerProvisionDelay: Never})
+	defer s.Close()
+	c := s.VultrClient()
+
+	instance, _, err := c.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-2c-4gb"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	lb, _, err := c.LoadBalancer.Create(ctx, &govultr.LoadBalancerReq{Region: "ewr", Label: "api"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lb.IPV4).NotTo(BeEmpty())
+
+	g.Consistently(func() string {
+		lb, _, err := c.LoadBalancer.Get(ctx, lb.ID)
+		g.Expect(err).NotTo(HaveOccurred())
+		return lb.Status
+	}, 100*time.Millisecond).Should(Equal("pending"))
+
+	g.Expect(c.LoadBalancer.Update(ctx, lb.ID, &govultr.LoadBalancerReq{Instances: []string{instance.ID}})).To(Succeed())
+	got, _ := s.LoadBalancer(lb.ID)
+	g.Expect(got.Instances).To(ConsistOf(instance.ID))
+
+	// Deleting an instance detaches it from its load balancers.
+	g.Expect(c.Instance.Delete(ctx, instance.ID)).To(Succeed())
+	got, _ = s.LoadBalancer(lb.ID)
+	g.Expect(got.Instances).To(BeEmpty())
+}
+
+func TestFaults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{})
+	defer s.Close()
+	c := s.VultrClient()
+	id := s.AddSSHKey(govultr.SSHKey{Name: "admin"})
+
+	// Transient faults are retried by govultr.
+	s.InjectFault(Fault{Path: "/v2/ssh-keys", StatusCode: http.StatusTooManyRequests, Times: 1})
+	s.InjectFault(Fault{Path: "/v2/ssh-keys", StatusCode: http.StatusInternalServerError, Times: 1})
+	key, _, err := c.SSHKey.Get(ctx, id)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(key.Name).To(Equal("admin"))
+	g.Expect(s.Requests(http.MethodGet, "/v2/ssh-keys")).To(Equal(3))
+
+	s.InjectFault(Fault{Method: http.MethodPost, StatusCode: http.StatusBadRequest, Message: "Invalid plan chosen."})
+	_, _, err = c.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-2c-4gb"})
+	g.Expect(err).To(MatchError(ContainSubstring("Invalid plan chosen.")))
+	s.ClearFaults()
+
+	s.InjectFault(Fault{Delay: time.Second})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, _, err = c.SSHKey.Get(timeoutCtx, id)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewServer(Options{APIKey: "secret"})
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/v2/instances")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+	_, _, _, err = s.VultrClient().Instance.List(context.Background(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestSimulationOptions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(Options{
+		InstanceStuckRatio: 1,
+		RateLimit:          1,
+		RateLimitBurst:     2,
+		PublicNetwork:      netip.MustParsePrefix("100.64.0.0/30"),
+	})
+	defer s.Close()
+	c := s.VultrClient()
+
+	// Addresses wrap around once the network is exhausted.
+	first, _, err := c.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-2c-4gb"})
+	g.Expect(err).NotTo(HaveOccurred())
+	second, _, err := c.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-2c-4gb"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect([]string{first.MainIP, second.MainIP}).To(ConsistOf("100.64.0.1", "100.64.0.2"))
+
+	// Requests over the rate limit are rejected and retried by govultr.
+	_, _, err = c.Instance.Get(ctx, first.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Requests(http.MethodGet, "/v2/instances")).To(Equal(2))
+
+	i, _ := s.Instance(first.ID)
+	g.Expect(i.Status).To(Equal("pending"))
+}
+
+func TestFaultProbability(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewServer(Options{})
+	defer s.Close()
+	id := s.AddSSHKey(govultr.SSHKey{Name: "admin"})
+
+	s.InjectFault(Fault{Path: "/v2/ssh-keys", StatusCode: http.StatusBadRequest, Probability: 0.5})
+	failed := 0
+	for range 100 {
+		if _, _, err := s.VultrClient().SSHKey.Get(context.Background(), id); err != nil {
+			failed++
+		}
+	}
+	g.Expect(failed).To(BeNumerically("~", 50, 30))
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+// collectTimeout bounds the list call made on every scrape.
+const collectTimeout = 10 * time.Second
+
+var instancesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "instances"),
+	"Number of Vultr instances backing VultrMachines, by cluster and subscription status.",
+	[]string{"cluster", "status"}, nil,
+)
+
+// InstanceCollector reports the number of instances per cluster and
+// subscription status, computed from the VultrMachines on every scrape so that
+// deleted machines never leave stale series behind.
+type InstanceCollector struct {
+	client client.Reader
+}
+
+// NewInstanceCollector returns an InstanceCollector listing VultrMachines through c,
+// which should be backed by the manager's cache.
+func NewInstanceCollector(c client.Reader) *InstanceCollector {
+	return &InstanceCollector{client: c}
+}
+
+// Describe implements prometheus.Collector.
+func (c *InstanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instancesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *InstanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	machines := &infrav1.VultrMachineList{}
+	if err := c.client.List(ctx, machines); err != nil {
+		ch <- prometheus.NewInvalidMetric(instancesDesc, err)
+		return
+	}
+
+	type key struct{ cluster, status string }
+	counts := map[key]int{}
+	for _, m := range machines.Items {
+		status := "unknown"
+		if m.Status.SubscriptionStatus != nil {
+			status = string(*m.Status.SubscriptionStatus)
+		}
+		counts[key{cluster: m.Labels[clusterv1.ClusterNameLabel], status: status}]++
+	}
+
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, float64(n), k.cluster, k.status)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics implements the Prometheus metrics exposed by the provider.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "capvultr"
+	subsystem = "api"
+)
+
+var (
+	// APIThrottledRequestsTotal counts requests the Vultr API answered with 429 Too Many Requests.
+	APIThrottledRequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "throttled_requests_total",
+		Help:      "Total number of Vultr API requests rejected with 429 Too Many Requests.",
+	})
+
+	// APIUnauthorizedRequestsTotal counts requests the Vultr API answered with 401 Unauthorized.
+	APIUnauthorizedRequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "unauthorized_requests_total",
+		Help:      "Total number of Vultr API requests rejected with 401 Unauthorized because of an invalid API key.",
+	})
+
+	// APIKeyReloadsTotal counts the API keys loaded from the API key file.
+	APIKeyReloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "key_reloads_total",
+		Help:      "Total number of attempts to reload the Vultr API key from its file by result.",
+	}, []string{"result"})
+
+	// APIRateLimiterWaitingRequests is the number of requests currently held back by the rate limiter.
+	APIRateLimiterWaitingRequests = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "rate_limiter_waiting_requests",
+		Help:      "Number of Vultr API requests currently waiting on the client side rate limiter.",
+	})
+
+	// APIRateLimiterWaitSeconds is the time requests spent waiting on the rate limiter.
+	APIRateLimiterWaitSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "rate_limiter_wait_seconds",
+		Help:      "Time Vultr API requests spent waiting on the client side rate limiter.",
+		Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	})
+
+	// APIRequestsTotal counts Vultr API requests by service, operation and status code.
+	APIRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "requests_total",
+		Help:      "Total number of Vultr API requests by service, operation and status code.",
+	}, []string{"service", "operation", "code"})
+
+	// APIRequestDurationSeconds is the latency of Vultr API requests.
+	APIRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of Vultr API requests by service, operation and status code.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"service", "operation", "code"})
+
+	// MachineProvisioningSeconds is the time from VultrMachine creation until it is ready for the first time.
+	MachineProvisioningSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "machine_provisioning_seconds",
+		Help:      "Time from VultrMachine creation until the instance is ready.",
+		Buckets:   []float64{30, 60, 90, 120, 180, 240, 300, 450, 600, 900, 1200, 1800},
+	}, []string{"region", "role"})
+
+	// LoadBalancerProvisioningSeconds is the time from VultrCluster creation until its load balancer is ready for the first time.
+	LoadBalancerProvisioningSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "loadbalancer_provisioning_seconds",
+		Help:      "Time from VultrCluster creation until the API server load balancer has an address.",
+		Buckets:   []float64{10, 30, 60, 90, 120, 180, 300, 600, 900},
+	}, []string{"region"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		APIThrottledRequestsTotal,
+		APIUnauthorizedRequestsTotal,
+		APIKeyReloadsTotal,
+		APIRateLimiterWaitingRequests,
+		APIRateLimiterWaitSeconds,
+		APIRequestsTotal,
+		APIRequestDurationSeconds,
+		MachineProvisioningSeconds,
+		LoadBalancerProvisioningSeconds,
+	)
+}
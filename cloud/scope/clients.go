@@ -17,15 +17,123 @@ limitations under the License.
 // Package scope implements scope types.
 package scope
 
-import "github.com/vultr/govultr/v3"
+import (
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+)
 
 // VultrClients hold all necessary clients to work with the Vultr API.
 type VultrAPIClients struct {
 	Instances     govultr.InstanceService
 	LoadBalancers govultr.LoadBalancerService
 	// Deprecated: VPC2 is no longer supported
-	VPC2s     govultr.VPC2Service //nolint:staticcheck
-	VPCs      govultr.VPCService
-	SSHKeys   govultr.SSHKeyService
-	Snapshots govultr.SnapshotService
+	VPC2s          govultr.VPC2Service //nolint:staticcheck
+	VPCs           govultr.VPCService
+	FirewallGroups govultr.FirewallGroupService
+	SSHKeys        govultr.SSHKeyService
+	Snapshots      govultr.SnapshotService
+	BlockStorages  govultr.BlockStorageService
+	BareMetals     govultr.BareMetalServerService
+	Plans          govultr.PlanService
+	Regions        govultr.RegionService
+}
+
+// NewVultrAPIClients returns the clients of all services of the given Vultr client.
+func NewVultrAPIClients(c *govultr.Client) VultrAPIClients {
+	clients := VultrAPIClients{}
+	clients.setDefaults(c)
+	return clients
+}
+
+// complete reports whether a client is set for every service.
+func (c *VultrAPIClients) complete() bool {
+	return c.Instances != nil &&
+		c.LoadBalancers != nil &&
+		c.VPC2s != nil &&
+		c.VPCs != nil &&
+		c.FirewallGroups != nil &&
+		c.SSHKeys != nil &&
+		c.Snapshots != nil &&
+		c.BlockStorages != nil &&
+		c.BareMetals != nil &&
+		c.Plans != nil &&
+		c.Regions != nil
+}
+
+// setDefaults sets the services that have no client yet to those of the given
+// Vultr client.
+func (c *VultrAPIClients) setDefaults(vultrClient *govultr.Client) {
+	if c.Instances == nil {
+		c.Instances = vultrClient.Instance
+	}
+	if c.LoadBalancers == nil {
+		c.LoadBalancers = vultrClient.LoadBalancer
+	}
+	if c.VPC2s == nil {
+		c.VPC2s = vultrClient.VPC2 //nolint:staticcheck
+	}
+	if c.VPCs == nil {
+		c.VPCs = vultrClient.VPC
+	}
+	if c.FirewallGroups == nil {
+		c.FirewallGroups = vultrClient.FirewallGroup
+	}
+	if c.SSHKeys == nil {
+		c.SSHKeys = vultrClient.SSHKey
+	}
+	if c.Snapshots == nil {
+		c.Snapshots = vultrClient.Snapshot
+	}
+	if c.BlockStorages == nil {
+		c.BlockStorages = vultrClient.BlockStorage
+	}
+	if c.BareMetals == nil {
+		c.BareMetals = vultrClient.BareMetalServer
+	}
+	if c.Plans == nil {
+		c.Plans = vultrClient.Plan
+	}
+	if c.Regions == nil {
+		c.Regions = vultrClient.Region
+	}
+}
+
+// ClientFactory creates the clients the reconcilers use to talk to the Vultr
+// API. It is called once per reconcile.
+type ClientFactory interface {
+	NewClients() (VultrAPIClients, error)
+}
+
+// ClientFactoryFunc is a function implementing ClientFactory.
+type ClientFactoryFunc func() (VultrAPIClients, error)
+
+// NewClients calls f.
+func (f ClientFactoryFunc) NewClients() (VultrAPIClients, error) {
+	return f()
+}
+
+// EnvClientFactory creates clients configured from the environment, see
+// CreateVultrClient.
+type EnvClientFactory struct{}
+
+// NewClients returns clients for all services of a new Vultr client.
+func (EnvClientFactory) NewClients() (VultrAPIClients, error) {
+	vultrClient, err := CreateVultrClient()
+	if err != nil {
+		return VultrAPIClients{}, err
+	}
+	return NewVultrAPIClients(vultrClient), nil
+}
+
+// NewClients returns the clients created by f. Without a factory no clients
+// are returned and the scopes create them from the environment.
+func NewClients(f ClientFactory) (VultrAPIClients, error) {
+	if f == nil {
+		return VultrAPIClients{}, nil
+	}
+	clients, err := f.NewClients()
+	if err != nil {
+		return VultrAPIClients{}, errors.Wrap(err, "failed to create Vultr clients")
+	}
+	return clients, nil
 }
@@ -6,9 +6,13 @@ import (
 
 	"github.com/pkg/errors"
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
 
 	"github.com/go-logr/logr"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -35,26 +39,13 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		return nil, errors.New("VultrCluster is required when creating a ClusterScope")
 	}
 
-	// Create the Vultr client.
-	vultrClient, err := CreateVultrClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Vultr Client: %w", err)
-	}
-
-	if params.Instances == nil {
-		params.Instances = vultrClient.Instance
-	}
-
-	if params.LoadBalancers == nil {
-		params.LoadBalancers = vultrClient.LoadBalancer
-	}
-
-	if params.Snapshots == nil {
-		params.Snapshots = vultrClient.Snapshot
-	}
-
-	if params.SSHKeys == nil {
-		params.SSHKeys = vultrClient.SSHKey
+	// Only create a Vultr client for the services that were not injected.
+	if !params.VultrAPIClients.complete() {
+		vultrClient, err := CreateVultrClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Vultr Client: %w", err)
+		}
+		params.VultrAPIClients.setDefaults(vultrClient)
 	}
 
 	helper, err := patch.NewHelper(params.VultrCluster, params.Client)
@@ -63,7 +54,7 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 	}
 
 	return &ClusterScope{
-		Logger:          params.Logger,
+		Logger:          params.Logger.WithValues(logging.ClusterKey, params.Cluster.Name),
 		client:          params.Client,
 		Cluster:         params.Cluster,
 		VultrCluster:    params.VultrCluster,
@@ -85,11 +76,36 @@ type ClusterScope struct {
 
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject(ctx context.Context) error {
-	return s.patchHelper.Patch(ctx, s.VultrCluster)
+	// Always update the readyCondition by summarizing the state of other conditions.
+	conditions.SetSummary(s.VultrCluster,
+		conditions.WithConditions(
+			infrav1.VPCReadyCondition,
+			infrav1.LoadBalancerReadyCondition,
+		),
+	)
+	if err := setV1Beta2Conditions(s.VultrCluster,
+		[]clusterv1.ConditionType{infrav1.LoadBalancerReadyCondition},
+		[]clusterv1.ConditionType{infrav1.VPCReadyCondition},
+	); err != nil {
+		return errors.Wrap(err, "failed to set v1beta2 conditions")
+	}
+
+	return s.patchHelper.Patch(ctx, s.VultrCluster,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.VPCReadyCondition,
+			infrav1.LoadBalancerReadyCondition,
+		}},
+		patch.WithOwnedV1Beta2Conditions{Conditions: []string{
+			clusterv1.ReadyV1Beta2Condition,
+			string(infrav1.VPCReadyCondition),
+			string(infrav1.LoadBalancerReadyCondition),
+		}},
+	)
 }
 
 func (s *ClusterScope) Close() error {
-	return s.patchHelper.Patch(context.TODO(), s.VultrCluster)
+	return s.PatchObject(context.TODO())
 }
 
 func (s *ClusterScope) AddFinalizer(ctx context.Context) error {
@@ -110,6 +126,16 @@ func (s *ClusterScope) APIServerLoadbalancersRef() *infrav1.VultrResourceReferen
 	return &s.VultrCluster.Status.Network.APIServerLoadbalancersRef
 }
 
+// APIServerLoadbalancerID returns the ID of the API server load balancer.
+// The ID is kept in the spec, which survives clusterctl move, and falls back
+// to the status for VultrClusters which only recorded it there.
+func (s *ClusterScope) APIServerLoadbalancerID() string {
+	if id := s.VultrCluster.Spec.Network.APIServerLoadbalancers.ID; id != "" {
+		return id
+	}
+	return s.VultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+}
+
 // UID returns the cluster UID.
 func (s *ClusterScope) UID() string {
 	return string(s.Cluster.UID)
@@ -125,9 +151,28 @@ func (s *ClusterScope) Name() string {
 	return s.Cluster.GetName()
 }
 
-// SetReady sets the VultrCluster Ready Status.
+// SetReady sets the VultrCluster Ready Status and marks it as provisioned.
 func (s *ClusterScope) SetReady() {
 	s.VultrCluster.Status.Ready = true
+	s.VultrCluster.Status.Initialization = &infrav1.VultrClusterInitializationStatus{Provisioned: ptr.To(true)}
+}
+
+// Provisioned returns whether the VultrCluster was provisioned before.
+func (s *ClusterScope) Provisioned() *bool {
+	if s.VultrCluster.Status.Initialization == nil {
+		return nil
+	}
+	return s.VultrCluster.Status.Initialization.Provisioned
+}
+
+// SetFailureMessage sets the VultrCluster status error message.
+func (s *ClusterScope) SetFailureMessage(v error) {
+	s.VultrCluster.Status.FailureMessage = ptr.To(v.Error())
+}
+
+// SetFailureReason sets the VultrCluster status error reason.
+func (s *ClusterScope) SetFailureReason(v capierrors.ClusterStatusError) {
+	s.VultrCluster.Status.FailureReason = &v
 }
 
 // SetControlPlaneEndpoint sets the VultrCluster status APIEndpoints.
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+func newClusterScopeParams(t *testing.T) ClusterScopeParams {
+	t.Helper()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	return ClusterScopeParams{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Logger:       log.Log,
+		Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+		VultrCluster: &infrav1.VultrCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+	}
+}
+
+func TestNewClusterScopeInjectedClients(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("VULTR_API_KEY", "")
+
+	// Injected clients need no environment.
+	params := newClusterScopeParams(t)
+	params.VultrAPIClients = NewVultrAPIClients(govultr.NewClient(nil))
+	s, err := NewClusterScope(params)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Instances).To(BeIdenticalTo(params.Instances))
+	g.Expect(s.VPCs).To(BeIdenticalTo(params.VPCs))
+
+	// Missing clients are created from the environment.
+	params = newClusterScopeParams(t)
+	params.Instances = govultr.NewClient(nil).Instance
+	_, err = NewClusterScope(params)
+	g.Expect(err).To(MatchError(ContainSubstring("VULTR_API_KEY is required")))
+
+	t.Setenv("VULTR_API_KEY", "key")
+	s, err = NewClusterScope(params)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Instances).To(BeIdenticalTo(params.Instances))
+	g.Expect(s.VultrAPIClients.complete()).To(BeTrue())
+}
+
+func TestNewClients(t *testing.T) {
+	g := NewWithT(t)
+
+	clients, err := NewClients(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clients).To(BeZero())
+
+	t.Setenv("VULTR_API_KEY", "")
+	_, err = NewClients(EnvClientFactory{})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to create Vultr clients")))
+
+	t.Setenv("VULTR_API_KEY", "key")
+	clients, err = NewClients(EnvClientFactory{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clients.complete()).To(BeTrue())
+}
+
+func TestAPIServerLoadbalancerID(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{VultrCluster: &infrav1.VultrCluster{}}
+	g.Expect(s.APIServerLoadbalancerID()).To(BeEmpty())
+
+	s.VultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID = "lb-status"
+	g.Expect(s.APIServerLoadbalancerID()).To(Equal("lb-status"))
+
+	// The spec survives clusterctl move and takes precedence.
+	s.VultrCluster.Spec.Network.APIServerLoadbalancers.ID = "lb-spec"
+	g.Expect(s.APIServerLoadbalancerID()).To(Equal("lb-spec"))
+}
@@ -2,24 +2,58 @@ package scope
 
 import (
 	"context"
+	"net/http"
 	"os"
 
 	"github.com/pkg/errors"
 	"github.com/vultr/govultr/v3"
 	"golang.org/x/oauth2"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/transport"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
 )
 
+// CreateVultrClient creates a Vultr client using the API key in VULTR_API_KEY.
+// VULTR_API_URL can point it at another endpoint, e.g. a fake API in tests.
 func CreateVultrClient() (*govultr.Client, error) {
 	apiKey := os.Getenv("VULTR_API_KEY")
 	if apiKey == "" {
 		return nil, errors.New("VULTR_API_KEY is required")
 	}
+	vultrClient, err := NewVultrClient(apiKey, os.Getenv("VULTR_API_URL"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid VULTR_API_URL")
+	}
+	return vultrClient, nil
+}
+
+// NewVultrClient creates a Vultr client authenticating with apiKey. It talks to
+// baseURL instead of the Vultr API if set.
+func NewVultrClient(apiKey, baseURL string) (*govultr.Client, error) {
+	// Keep the key out of the logs, e.g. when an error echoes a request.
+	logging.AddSecret(apiKey)
+
 	config := &oauth2.Config{}
 	ctx := context.Background()
 	tokenSource := config.TokenSource(ctx, &oauth2.Token{AccessToken: apiKey})
-	vultrClient := govultr.NewClient(oauth2.NewClient(ctx, tokenSource))
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: tokenSource,
+			// All clients using the same API key share one rate limiter.
+			Base: transport.NewTracingTransport(
+				transport.NewRateLimitedTransport(apiKey, transport.NewInstrumentedTransport(http.DefaultTransport)),
+			),
+		},
+	}
+	vultrClient := govultr.NewClient(httpClient)
 	vultrClient.SetUserAgent("vultr-cluster-api")
 
+	if baseURL != "" {
+		if err := vultrClient.SetBaseURL(baseURL); err != nil {
+			return nil, err
+		}
+	}
+
 	return vultrClient, nil
 }
 
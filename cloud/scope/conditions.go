@@ -0,0 +1,75 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
+)
+
+// conditionsObject is an object carrying both v1beta1 and v1beta2 conditions.
+type conditionsObject interface {
+	conditions.Setter
+	v1beta2conditions.Setter
+}
+
+// setV1Beta2Conditions mirrors the v1beta1 conditions of obj into its v1beta2
+// conditions and summarizes them into the v1beta2 Ready condition. Missing
+// required conditions are reported as Unknown, missing optional ones are
+// ignored. Informational conditions are mirrored but not summarized.
+func setV1Beta2Conditions(obj conditionsObject, required, optional []clusterv1.ConditionType, informational ...clusterv1.ConditionType) error {
+	var requiredTypes, optionalTypes []string
+	for _, t := range required {
+		requiredTypes = append(requiredTypes, string(t))
+	}
+	for _, t := range optional {
+		optionalTypes = append(optionalTypes, string(t))
+	}
+
+	mirrored := slices.Concat(requiredTypes, optionalTypes)
+	for _, t := range informational {
+		mirrored = append(mirrored, string(t))
+	}
+	for _, t := range mirrored {
+		c := conditions.Get(obj, clusterv1.ConditionType(t))
+		if c == nil {
+			v1beta2conditions.Delete(obj, t)
+			continue
+		}
+
+		reason := c.Reason
+		if reason == "" {
+			// v1beta1 conditions which are True have no reason, v1beta2 conditions always do.
+			reason = t
+		}
+		v1beta2conditions.Set(obj, metav1.Condition{
+			Type:    t,
+			Status:  metav1.ConditionStatus(c.Status),
+			Reason:  reason,
+			Message: c.Message,
+		})
+	}
+
+	return v1beta2conditions.SetSummaryCondition(obj, obj, clusterv1.ReadyV1Beta2Condition,
+		v1beta2conditions.ForConditionTypes(append(requiredTypes, optionalTypes...)),
+		v1beta2conditions.IgnoreTypesIfMissing(optionalTypes),
+	)
+}
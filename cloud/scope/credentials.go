@@ -0,0 +1,153 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/transport"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+)
+
+// APIKeyFileEnv is the environment variable holding the path of a file with
+// the Vultr API key. The key is reloaded when the file changes, so that it can
+// be rotated without restarting the manager.
+const APIKeyFileEnv = "VULTR_API_KEY_FILE"
+
+// fileCredentials is an API key and the client authenticating with it.
+type fileCredentials struct {
+	apiKey string
+	client *govultr.Client
+}
+
+// FileClientFactory creates clients authenticating with the API key read from
+// a file. It watches the file and replaces the client when the key changes.
+// Reconciles which already got their clients keep using the previous key.
+type FileClientFactory struct {
+	path        string
+	baseURL     string
+	logger      logr.Logger
+	credentials atomic.Pointer[fileCredentials]
+}
+
+// NewFileClientFactory returns a factory using the API key in the file at
+// path. It talks to baseURL instead of the Vultr API if set. Start must be
+// called to pick up changes of the key.
+func NewFileClientFactory(path, baseURL string, logger logr.Logger) (*FileClientFactory, error) {
+	f := &FileClientFactory{path: path, baseURL: baseURL, logger: logger}
+	if _, err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// NewClients returns clients for all services of the client using the
+// current API key.
+func (f *FileClientFactory) NewClients() (VultrAPIClients, error) {
+	return NewVultrAPIClients(f.credentials.Load().client), nil
+}
+
+// reload reads the API key from the file and replaces the client if the key
+// changed. It reports whether it did.
+func (f *FileClientFactory) reload() (bool, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read the Vultr API key")
+	}
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return false, errors.Errorf("the Vultr API key file %s is empty", f.path)
+	}
+	current := f.credentials.Load()
+	if current != nil && current.apiKey == apiKey {
+		return false, nil
+	}
+
+	client, err := NewVultrClient(apiKey, f.baseURL)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid VULTR_API_URL")
+	}
+	f.credentials.Store(&fileCredentials{apiKey: apiKey, client: client})
+	if current != nil {
+		// Rotated keys are not used again, don't keep their rate limiters
+		// and stop scanning log lines for them.
+		transport.ReleaseRateLimiter(current.apiKey)
+		logging.RemoveSecret(current.apiKey)
+	}
+	return true, nil
+}
+
+// Start watches the API key file until ctx is done. It implements
+// manager.Runnable.
+func (f *FileClientFactory) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to watch the Vultr API key file")
+	}
+	defer watcher.Close()
+
+	// Secret volumes are updated by swapping a symlink in the directory,
+	// which a watch on the file itself would miss.
+	if err := watcher.Add(filepath.Dir(f.path)); err != nil {
+		return errors.Wrap(err, "failed to watch the Vultr API key file")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			changed, err := f.reload()
+			switch {
+			case err != nil:
+				// The file may be written in several steps, keep the
+				// previous key until it is complete.
+				metrics.APIKeyReloadsTotal.WithLabelValues("error").Inc()
+				f.logger.Error(err, "Failed to reload the Vultr API key, keeping the previous one")
+			case changed:
+				metrics.APIKeyReloadsTotal.WithLabelValues("success").Inc()
+				f.logger.Info("Reloaded the Vultr API key", "path", f.path)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			f.logger.Error(err, "Failed to watch the Vultr API key file")
+		}
+	}
+}
+
+// NeedLeaderElection returns false, every replica must follow the key.
+func (f *FileClientFactory) NeedLeaderElection() bool {
+	return false
+}
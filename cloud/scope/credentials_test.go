@@ -0,0 +1,98 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+)
+
+// writeAPIKey replaces the API key file the way the kubelet updates Secret
+// volumes: the new content is written next to it and renamed over it.
+func writeAPIKey(t *testing.T, path, key string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(key+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileClientFactory(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{APIKey: "old-api-token"})
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "apiKey")
+	_, err := NewFileClientFactory(path, s.URL, log.Log)
+	g.Expect(err).To(MatchError(ContainSubstring("failed to read the Vultr API key")))
+	writeAPIKey(t, path, "")
+	_, err = NewFileClientFactory(path, s.URL, log.Log)
+	g.Expect(err).To(MatchError(ContainSubstring("is empty")))
+
+	writeAPIKey(t, path, "old-api-token")
+	f, err := NewFileClientFactory(path, s.URL, log.Log)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.NeedLeaderElection()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- f.Start(ctx) }()
+
+	listPlans := func() error {
+		clients, err := f.NewClients()
+		g.Expect(err).NotTo(HaveOccurred())
+		_, _, _, err = clients.Plans.List(context.Background(), "", nil)
+		return err
+	}
+	g.Expect(listPlans()).To(Succeed())
+
+	// Requests fail once the key was revoked.
+	unauthorized := testutil.ToFloat64(metrics.APIUnauthorizedRequestsTotal)
+	s.SetAPIKey("new-api-token")
+	g.Expect(listPlans()).NotTo(Succeed())
+	g.Expect(testutil.ToFloat64(metrics.APIUnauthorizedRequestsTotal)).To(BeNumerically(">", unauthorized))
+
+	// New clients use the rotated key as soon as the file is updated.
+	writeAPIKey(t, path, "new-api-token")
+	g.Eventually(listPlans, 5*time.Second, 10*time.Millisecond).Should(Succeed())
+	// Only the current key is redacted from the logs.
+	g.Expect(logging.Redact("new-api-token")).To(Equal(logging.Redacted))
+	g.Expect(logging.Redact("old-api-token")).To(Equal("old-api-token"))
+
+	// An invalid update keeps the previous key.
+	writeAPIKey(t, path, "")
+	g.Consistently(listPlans, 200*time.Millisecond, 10*time.Millisecond).Should(Succeed())
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
@@ -18,21 +18,26 @@ package scope
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 )
 
 // MachineScopeParams defines the input parameters used to create a new MachineScope.
@@ -83,8 +88,11 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 	}
 
 	return &MachineScope{
-		client:       params.Client,
-		Logger:       params.Logger,
+		client: params.Client,
+		Logger: params.Logger.WithValues(
+			logging.ClusterKey, params.Cluster.Name,
+			logging.MachineKey, params.VultrMachine.Name,
+		),
 		Cluster:      params.Cluster,
 		Machine:      params.Machine,
 		VultrCluster: params.VultrCluster,
@@ -94,17 +102,95 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 }
 
 func (s *MachineScope) Close() error {
-	return s.patchHelper.Patch(context.TODO(), s.VultrMachine)
+	return s.PatchObject(context.TODO())
 }
 
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
-	return m.patchHelper.Patch(ctx, m.VultrMachine)
+	// Always update the readyCondition by summarizing the state of other conditions.
+	conditions.SetSummary(m.VultrMachine,
+		conditions.WithConditions(
+			infrav1.BootstrapDataAvailableCondition,
+			infrav1.PreflightChecksPassedCondition,
+			infrav1.VPCReadyCondition,
+			infrav1.FirewallReadyCondition,
+			infrav1.InstanceProvisionedCondition,
+			infrav1.InstanceRunningCondition,
+			infrav1.LoadBalancerAttachedCondition,
+			infrav1.DataVolumesReadyCondition,
+		),
+	)
+
+	if err := setV1Beta2Conditions(m.VultrMachine,
+		[]clusterv1.ConditionType{
+			infrav1.BootstrapDataAvailableCondition,
+			infrav1.InstanceProvisionedCondition,
+			infrav1.InstanceRunningCondition,
+		},
+		[]clusterv1.ConditionType{
+			infrav1.PreflightChecksPassedCondition,
+			infrav1.VPCReadyCondition,
+			infrav1.FirewallReadyCondition,
+			infrav1.LoadBalancerAttachedCondition,
+			infrav1.DataVolumesReadyCondition,
+		},
+		infrav1.PlanUpToDateCondition,
+	); err != nil {
+		return errors.Wrap(err, "failed to set v1beta2 conditions")
+	}
+
+	return m.patchHelper.Patch(ctx, m.VultrMachine,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.BootstrapDataAvailableCondition,
+			infrav1.PreflightChecksPassedCondition,
+			infrav1.VPCReadyCondition,
+			infrav1.FirewallReadyCondition,
+			infrav1.InstanceProvisionedCondition,
+			infrav1.InstanceRunningCondition,
+			infrav1.LoadBalancerAttachedCondition,
+			infrav1.PlanUpToDateCondition,
+			infrav1.DataVolumesReadyCondition,
+		}},
+		patch.WithOwnedV1Beta2Conditions{Conditions: []string{
+			clusterv1.ReadyV1Beta2Condition,
+			string(infrav1.BootstrapDataAvailableCondition),
+			string(infrav1.PreflightChecksPassedCondition),
+			string(infrav1.VPCReadyCondition),
+			string(infrav1.FirewallReadyCondition),
+			string(infrav1.InstanceProvisionedCondition),
+			string(infrav1.InstanceRunningCondition),
+			string(infrav1.LoadBalancerAttachedCondition),
+			string(infrav1.PlanUpToDateCondition),
+			string(infrav1.DataVolumesReadyCondition),
+		}},
+	)
 }
 
-// SetReady sets the VultrMachine Ready Status.
+// SetReady sets the VultrMachine Ready Status and marks it as provisioned.
 func (m *MachineScope) SetReady() {
 	m.VultrMachine.Status.Ready = true
+	m.VultrMachine.Status.Initialization = &infrav1.VultrMachineInitializationStatus{Provisioned: ptr.To(true)}
+}
+
+// SetAppliedAdditionalTags records the additional tags applied to the
+// instance or bare metal server.
+func (m *MachineScope) SetAppliedAdditionalTags(tags infrav1.Tags) {
+	m.VultrMachine.Status.AppliedAdditionalTags = slices.Clone(tags)
+}
+
+// Provisioned returns whether the VultrMachine was provisioned before. It
+// stays true when the machine becomes not ready again.
+func (m *MachineScope) Provisioned() *bool {
+	if m.VultrMachine.Status.Initialization == nil {
+		return nil
+	}
+	return m.VultrMachine.Status.Initialization.Provisioned
+}
+
+// SetNotReady sets the VultrMachine Ready Status to false.
+func (m *MachineScope) SetNotReady() {
+	m.VultrMachine.Status.Ready = false
 }
 
 // AddFinalizer adds a finalizer if not present and immediately patches the
@@ -117,7 +203,9 @@ func (m *MachineScope) AddFinalizer(ctx context.Context) error {
 	return nil
 }
 
-// GetInstanceID returns the VultrMachine instance id by parsing Spec.ProviderID.
+// GetInstanceID returns the ID of the VultrMachine instance or bare metal
+// server by parsing Spec.ProviderID. The provider ID does not tell them
+// apart, whether it is a bare metal server is told by Spec.MachineType.
 func (m *MachineScope) GetInstanceID() string {
 	id := m.GetProviderID()
 
@@ -141,11 +229,19 @@ func (m *MachineScope) GetProviderID() string {
 }
 
 // SetProviderID sets the VultrMachine providerID in spec from instance id.
+// Instances and bare metal servers both have provider IDs of the form
+// vultr://<id>, which is what the kubelet and the Vultr cloud controller
+// manager set on their Nodes.
 func (m *MachineScope) SetProviderID(instanceID string) {
 	pid := fmt.Sprintf("vultr://%s", instanceID)
 	m.VultrMachine.Spec.ProviderID = ptr.To(pid)
 }
 
+// IsBareMetal returns true if the machine is backed by a bare metal server.
+func (m *MachineScope) IsBareMetal() bool {
+	return m.VultrMachine.Spec.MachineType == infrav1.MachineTypeBareMetal
+}
+
 // Name returns the VultrMachine name.
 func (m *MachineScope) Name() string {
 	return m.VultrMachine.Name
@@ -156,7 +252,11 @@ func (m *MachineScope) Namespace() string {
 	return m.VultrMachine.Namespace
 }
 
-func (m *MachineScope) GetBootstrapData() (string, error) {
+// GetBootstrapData returns the bootstrap data from the secret referenced by the Machine.
+func (m *MachineScope) GetBootstrapData(ctx context.Context) (_ string, reterr error) {
+	ctx, span := tracing.StartSpan(ctx, "MachineScope.GetBootstrapData", attribute.String("machine", m.Name()))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
 		m.Info("Bootstrap data secret reference is nil")
 		return "", errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
@@ -164,10 +264,10 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 
 	secretName := *m.Machine.Spec.Bootstrap.DataSecretName
 	key := types.NamespacedName{Namespace: m.Namespace(), Name: secretName}
-	m.Info("Attempting to retrieve bootstrap data secret", "namespace", key.Namespace, "name", key.Name)
+	m.V(2).Info("Attempting to retrieve bootstrap data secret", "namespace", key.Namespace, "name", key.Name)
 
 	secret := &corev1.Secret{}
-	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+	if err := m.client.Get(ctx, key, secret); err != nil {
 		m.Error(err, "Failed to retrieve bootstrap data secret", "namespace", key.Namespace, "name", key.Name)
 		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for VultrMachine %s/%s", m.Namespace(), m.Name())
 	}
@@ -178,8 +278,8 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
 	}
 
-	// Log the retrieved bootstrap data (truncated to avoid logging sensitive information)
-	m.Info("Successfully retrieved bootstrap data", "value", string(value)[:min(50, len(value))])
+	// The bootstrap data contains credentials and must never be logged.
+	m.V(2).Info("Successfully retrieved bootstrap data", "namespace", key.Namespace, "name", key.Name)
 	return string(value), nil
 }
 
@@ -196,6 +296,18 @@ func (m *MachineScope) Role() string {
 	return infrav1.NodeRoleTagValue
 }
 
+// AdditionalTags returns the additional tags of the VultrCluster followed by
+// those of the VultrMachine, without duplicates.
+func (m *MachineScope) AdditionalTags() infrav1.Tags {
+	var tags infrav1.Tags
+	for _, tag := range slices.Concat(m.VultrCluster.Spec.AdditionalTags, m.VultrMachine.Spec.AdditionalTags) {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // GetInstanceStatus returns the VultrMachine instance status from the status.
 func (m *MachineScope) GetInstanceStatus() *infrav1.SubscriptionStatus {
 	return m.VultrMachine.Status.SubscriptionStatus
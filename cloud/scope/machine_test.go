@@ -0,0 +1,56 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+func TestProviderID(t *testing.T) {
+	g := NewWithT(t)
+
+	m := &MachineScope{VultrMachine: &infrav1.VultrMachine{}}
+	g.Expect(m.GetInstanceID()).To(BeEmpty())
+
+	m.SetProviderID("1234")
+	g.Expect(m.GetProviderID()).To(Equal("vultr://1234"))
+	g.Expect(m.GetInstanceID()).To(Equal("1234"))
+
+	m.VultrMachine.Spec.MachineType = infrav1.MachineTypeBareMetal
+	m.SetProviderID("5678")
+	g.Expect(m.GetProviderID()).To(Equal("vultr://5678"))
+	g.Expect(m.GetInstanceID()).To(Equal("5678"))
+
+	m.VultrMachine.Spec.ProviderID = ptr.To("aws:///1234")
+	g.Expect(m.GetInstanceID()).To(BeEmpty())
+}
+
+func TestAdditionalTags(t *testing.T) {
+	g := NewWithT(t)
+
+	m := &MachineScope{VultrCluster: &infrav1.VultrCluster{}, VultrMachine: &infrav1.VultrMachine{}}
+	g.Expect(m.AdditionalTags()).To(BeEmpty())
+
+	m.VultrCluster.Spec.AdditionalTags = []string{"cost-center:42", "env:prod"}
+	m.VultrMachine.Spec.AdditionalTags = []string{"team:platform", "env:prod"}
+	g.Expect(m.AdditionalTags()).To(Equal(infrav1.Tags{"cost-center:42", "env:prod", "team:platform"}))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+)
+
+// MachineTemplateScopeParams defines the input parameters used to create a
+// new MachineTemplateScope.
+type MachineTemplateScopeParams struct {
+	VultrAPIClients
+	Client               client.Client
+	Logger               logr.Logger
+	VultrMachineTemplate *infrav1.VultrMachineTemplate
+}
+
+// NewMachineTemplateScope creates a new MachineTemplateScope from the supplied
+// parameters. This is meant to be called for each reconcile iteration.
+func NewMachineTemplateScope(params MachineTemplateScopeParams) (*MachineTemplateScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("Client is required when creating a MachineTemplateScope")
+	}
+	if params.VultrMachineTemplate == nil {
+		return nil, errors.New("VultrMachineTemplate is required when creating a MachineTemplateScope")
+	}
+
+	// Only create a Vultr client for the services that were not injected.
+	if !params.VultrAPIClients.complete() {
+		vultrClient, err := CreateVultrClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Vultr Client: %w", err)
+		}
+		params.VultrAPIClients.setDefaults(vultrClient)
+	}
+
+	helper, err := patch.NewHelper(params.VultrMachineTemplate, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &MachineTemplateScope{
+		Logger:               params.Logger.WithValues(logging.TemplateKey, params.VultrMachineTemplate.Name),
+		VultrAPIClients:      params.VultrAPIClients,
+		VultrMachineTemplate: params.VultrMachineTemplate,
+		patchHelper:          helper,
+	}, nil
+}
+
+// MachineTemplateScope defines the basic context for reconciling a
+// VultrMachineTemplate.
+type MachineTemplateScope struct {
+	logr.Logger
+	patchHelper *patch.Helper
+
+	VultrAPIClients
+	VultrMachineTemplate *infrav1.VultrMachineTemplate
+}
+
+// Name returns the VultrMachineTemplate name.
+func (s *MachineTemplateScope) Name() string {
+	return s.VultrMachineTemplate.Name
+}
+
+// PatchObject persists the machine template status.
+func (s *MachineTemplateScope) PatchObject(ctx context.Context) error {
+	return s.patchHelper.Patch(ctx, s.VultrMachineTemplate)
+}
+
+// Close the MachineTemplateScope by updating the machine template status.
+func (s *MachineTemplateScope) Close() error {
+	return s.PatchObject(context.TODO())
+}
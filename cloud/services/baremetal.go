@@ -0,0 +1,162 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+	"github.com/vultr/cluster-api-provider-vultr/util"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// BareMetalPlanPrefix starts the IDs of bare metal plans.
+const BareMetalPlanPrefix = "vbm-"
+
+// GetBareMetal retrieves a bare metal server by its ID. It returns nil if the
+// server does not exist.
+func (s *Service) GetBareMetal(id string) (_ *govultr.BareMetalServer, reterr error) {
+	ctx, span := s.startSpan("GetBareMetal", attribute.String("baremetal.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	if id == "" {
+		return nil, nil
+	}
+
+	s.scope.V(2).Info("Looking for bare metal server by ID", logging.InstanceIDKey, id)
+	server, resp, err := s.scope.BareMetals.Get(ctx, id)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get bare metal server with ID %q", id)
+	}
+	return server, nil
+}
+
+// CreateBareMetal creates a bare metal server for a machine.
+func (s *Service) CreateBareMetal(scope *scope.MachineScope) (_ *govultr.BareMetalServer, reterr error) {
+	ctx, span := s.startSpan("CreateBareMetal", attribute.String("machine", scope.Name()))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	log := s.scope.WithValues(logging.MachineKey, scope.Name())
+	log.V(2).Info("Creating a bare metal server for a machine")
+
+	userData, err := s.userData(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	sshKeyIDs, err := s.sshKeyIDs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &govultr.BareMetalCreate{
+		Label:      scope.Name(),
+		Hostname:   scope.Name(),
+		Region:     scope.VultrMachine.Spec.Region,
+		Plan:       scope.VultrMachine.Spec.PlanID,
+		SSHKeyIDs:  sshKeyIDs,
+		SnapshotID: scope.VultrMachine.Spec.Snapshot,
+		UserData:   userData,
+		EnableIPv6: util.Pointer(ptr.Deref(scope.VultrMachine.Spec.EnableIPv6, true)),
+		Tags:       s.MachineTags(scope),
+	}
+
+	server, resp, err := s.scope.BareMetals.Create(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(classifyError(resp, err), "failed to create bare metal server")
+	}
+	log.V(2).Info("Successfully created bare metal server", logging.InstanceIDKey, server.ID)
+	return server, nil
+}
+
+// UpdateBareMetalTags replaces the tags of a bare metal server.
+func (s *Service) UpdateBareMetalTags(id string, tags infrav1.Tags) (reterr error) {
+	ctx, span := s.startSpan("UpdateBareMetalTags", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Updating bare metal server tags", logging.InstanceIDKey, id, "tags", tags)
+	if _, resp, err := s.scope.BareMetals.Update(ctx, id, &govultr.BareMetalUpdate{Tags: tags}); err != nil {
+		return errors.Wrapf(classifyError(resp, err), "failed to update tags of bare metal server with id %q", id)
+	}
+	return nil
+}
+
+// DeleteBareMetal deletes a bare metal server.
+func (s *Service) DeleteBareMetal(id string) (reterr error) {
+	ctx, span := s.startSpan("DeleteBareMetal", attribute.String("baremetal.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	if id == "" {
+		return errors.New("cannot delete bare metal server. server does not have an id")
+	}
+	if err := s.scope.BareMetals.Delete(ctx, id); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to delete bare metal server with id %q", id)
+	}
+	return nil
+}
+
+// GetBareMetalVPCAddress returns the address of a bare metal server in the
+// VPC, or an empty string if the server is not attached to it.
+func (s *Service) GetBareMetalVPCAddress(id, vpcID string) (_ string, reterr error) {
+	ctx, span := s.startSpan("GetBareMetalVPCAddress", attribute.String("baremetal.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	vpcs, resp, err := s.scope.BareMetals.ListVPCInfo(ctx, id)
+	if err != nil {
+		return "", errors.Wrapf(classifyError(resp, err), "failed to list VPCs of bare metal server with id %q", id)
+	}
+	for _, vpc := range vpcs {
+		if vpc.ID == vpcID {
+			return vpc.IPAddress, nil
+		}
+	}
+	return "", nil
+}
+
+// AttachBareMetalVPC attaches a bare metal server to a VPC. Unlike instances,
+// bare metal servers cannot be attached to a VPC when they are created.
+func (s *Service) AttachBareMetalVPC(id, vpcID string) (reterr error) {
+	ctx, span := s.startSpan("AttachBareMetalVPC", attribute.String("baremetal.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	if err := s.scope.BareMetals.AttachVPC(ctx, id, vpcID); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to attach bare metal server with id %q to VPC %q", id, vpcID)
+	}
+	return nil
+}
+
+// GetBareMetalAddress converts the IPs of a bare metal server to
+// corev1.NodeAddresses. internalIP is its address in the VPC, if any.
+func (s *Service) GetBareMetalAddress(server *govultr.BareMetalServer, internalIP string) []corev1.NodeAddress {
+	return s.nodeAddresses(&govultr.Instance{
+		ID:         server.ID,
+		InternalIP: internalIP,
+		MainIP:     server.MainIP,
+		V6MainIP:   server.V6MainIP,
+		Hostname:   server.Label,
+	})
+}
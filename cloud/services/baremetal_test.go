@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+func TestBareMetalLifecycle(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	machineScope.VultrMachine.Spec.MachineType = infrav1.MachineTypeBareMetal
+	machineScope.VultrMachine.Spec.PlanID = "vbm-4c-32gb"
+
+	server, err := svc.CreateBareMetal(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(server.Label).To(Equal("test-control-plane-abcde"))
+	g.Expect(server.Plan).To(Equal("vbm-4c-32gb"))
+	g.Expect(server.Tags).To(ContainElement(infrav1.ClusterNameTag("test")))
+	g.Expect(svc.UpdateBareMetalTags(server.ID, append(server.Tags, "cost-center:42"))).To(Succeed())
+	userData, _ := s.BareMetalUserData(server.ID)
+	g.Expect(userData).To(ContainSubstring("kubeadm init"))
+
+	got, err := svc.GetBareMetal(server.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Status).To(Equal("active"))
+	g.Expect(got.Tags).To(ContainElement("cost-center:42"))
+
+	vpcID := s.AddVPC(govultr.VPC{Region: "ewr", V4Subnet: "10.1.0.0", V4SubnetMask: 20})
+	address, err := svc.GetBareMetalVPCAddress(server.ID, vpcID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(address).To(BeEmpty())
+	g.Expect(svc.AttachBareMetalVPC(server.ID, vpcID)).To(Succeed())
+	address, err = svc.GetBareMetalVPCAddress(server.ID, vpcID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(address).NotTo(BeEmpty())
+	g.Expect(svc.GetBareMetalAddress(got, address)).To(ContainElements(
+		corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: address},
+		corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: got.MainIP},
+		corev1.NodeAddress{Type: corev1.NodeHostName, Address: "test-control-plane-abcde"},
+	))
+
+	g.Expect(svc.DeleteBareMetal(server.ID)).To(Succeed())
+	got, err = svc.GetBareMetal(server.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+
+	// Instance plans are rejected for bare metal servers.
+	machineScope.VultrMachine.Spec.PlanID = "vc2-2c-4gb"
+	_, err = svc.CreateBareMetal(machineScope)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTerminalError(err)).To(BeTrue())
+}
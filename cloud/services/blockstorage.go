@@ -0,0 +1,193 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// DataVolumeLabel returns the Vultr label of the data volume with the given
+// label of a machine.
+func DataVolumeLabel(machineName, label string) string {
+	return machineName + "-" + label
+}
+
+// blockType returns the Vultr block storage type of a VolumeType.
+func blockType(t infrav1.VolumeType) string {
+	if t == infrav1.VolumeTypeHDD {
+		return "storage_opt"
+	}
+	return "high_perf"
+}
+
+// GetBlockStorage retrieves a block storage by its ID. It returns nil if the
+// block storage does not exist.
+func (s *Service) GetBlockStorage(id string) (_ *govultr.BlockStorage, reterr error) {
+	ctx, span := s.startSpan("GetBlockStorage", attribute.String("blockstorage.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	block, resp, err := s.scope.BlockStorages.Get(ctx, id)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get block storage with ID %q", id)
+	}
+	return block, nil
+}
+
+// FindBlockStorage returns the block storage with the given label in region,
+// or nil if there is none.
+func (s *Service) FindBlockStorage(region, label string) (_ *govultr.BlockStorage, reterr error) {
+	ctx, span := s.startSpan("FindBlockStorage", attribute.String("blockstorage.label", label))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	opts := &govultr.ListOptions{PerPage: 100}
+	for {
+		blocks, meta, resp, err := s.scope.BlockStorages.List(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrapf(classifyError(resp, err), "failed to list block storages")
+		}
+		for i := range blocks {
+			if blocks[i].Region == region && blocks[i].Label == label {
+				return &blocks[i], nil
+			}
+		}
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return nil, nil
+		}
+		opts.Cursor = meta.Links.Next
+	}
+}
+
+// CreateBlockStorage creates the block storage of a data volume of the
+// machine in region.
+func (s *Service) CreateBlockStorage(machineName, region string, volume infrav1.DataVolume) (_ *govultr.BlockStorage, reterr error) {
+	label := DataVolumeLabel(machineName, volume.Label)
+	ctx, span := s.startSpan("CreateBlockStorage", attribute.String("blockstorage.label", label))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Creating block storage", logging.MachineKey, machineName, "label", label)
+	block, resp, err := s.scope.BlockStorages.Create(ctx, &govultr.BlockStorageCreate{
+		Region:    region,
+		SizeGB:    volume.SizeGB,
+		Label:     label,
+		BlockType: blockType(volume.Type),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to create block storage %q", label)
+	}
+	return block, nil
+}
+
+// AttachBlockStorage attaches a block storage to a running instance.
+func (s *Service) AttachBlockStorage(id, instanceID string) (reterr error) {
+	ctx, span := s.startSpan("AttachBlockStorage", attribute.String("blockstorage.id", id), attribute.String("instance.id", instanceID))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Attaching block storage", logging.BlockStorageIDKey, id, logging.InstanceIDKey, instanceID)
+	if err := s.scope.BlockStorages.Attach(ctx, id, &govultr.BlockStorageAttach{InstanceID: instanceID, Live: util.Pointer(true)}); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to attach block storage with id %q to instance %q", id, instanceID)
+	}
+	return nil
+}
+
+// DetachBlockStorage detaches a block storage from its instance.
+func (s *Service) DetachBlockStorage(id string) (reterr error) {
+	ctx, span := s.startSpan("DetachBlockStorage", attribute.String("blockstorage.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Detaching block storage", logging.BlockStorageIDKey, id)
+	if err := s.scope.BlockStorages.Detach(ctx, id, &govultr.BlockStorageDetach{Live: util.Pointer(true)}); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to detach block storage with id %q", id)
+	}
+	return nil
+}
+
+// DeleteBlockStorage deletes a detached block storage. Block storages which
+// no longer exist are ignored.
+func (s *Service) DeleteBlockStorage(id string) (reterr error) {
+	ctx, span := s.startSpan("DeleteBlockStorage", attribute.String("blockstorage.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Deleting block storage", logging.BlockStorageIDKey, id)
+	if err := s.scope.BlockStorages.Delete(ctx, id); err != nil {
+		err = classifyError(nil, err)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete block storage with id %q", id)
+	}
+	return nil
+}
+
+// dataVolumeCommands returns the commands formatting and mounting the data
+// volumes with a filesystem on first boot. Each command waits until its
+// volume was attached.
+func dataVolumeCommands(volumes []infrav1.DataVolume, statuses []infrav1.DataVolumeStatus) []string {
+	var commands []string
+	for _, volume := range volumes {
+		if volume.Filesystem == "" {
+			continue
+		}
+		var mountID string
+		for _, status := range statuses {
+			if status.Label == volume.Label {
+				mountID = status.MountID
+			}
+		}
+		if mountID == "" {
+			continue
+		}
+
+		dev := shellQuote("/dev/disk/by-id/virtio-" + mountID)
+		filesystem := shellQuote(volume.Filesystem)
+		cmd := []string{
+			fmt.Sprintf("while [ ! -e %s ]; do sleep 2; done", dev),
+			fmt.Sprintf("{ blkid %s || mkfs -t %s %s; }", dev, filesystem, dev),
+		}
+		if volume.MountPath != "" {
+			mountPath := shellQuote(volume.MountPath)
+			fstab := fmt.Sprintf("/dev/disk/by-id/virtio-%s %s %s defaults,nofail 0 2", mountID, volume.MountPath, volume.Filesystem)
+			cmd = append(cmd,
+				fmt.Sprintf("mkdir -p %s", mountPath),
+				fmt.Sprintf("echo %s >> /etc/fstab", shellQuote(fstab)),
+				fmt.Sprintf("mount %s", mountPath),
+			)
+		}
+		commands = append(commands, strings.Join(cmd, " && "))
+	}
+	return commands
+}
+
+// shellQuote quotes s as a single word for sh, the values are validated by
+// the API but run as root on the node.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+func TestBlockStorageLifecycle(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	volume := infrav1.DataVolume{Label: "etcd", SizeGB: 40, Type: infrav1.VolumeTypeHDD}
+	block, err := svc.CreateBlockStorage(machineScope.Name(), "ewr", volume)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(block.Label).To(Equal("test-control-plane-abcde-etcd"))
+	g.Expect(block.BlockType).To(Equal("storage_opt"))
+	g.Expect(block.MountID).NotTo(BeEmpty())
+
+	found, err := svc.FindBlockStorage("ewr", block.Label)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).NotTo(BeNil())
+	g.Expect(found.ID).To(Equal(block.ID))
+	found, err = svc.FindBlockStorage("ams", block.Label)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeNil())
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.AttachBlockStorage(block.ID, instance.ID)).To(Succeed())
+	got, err := svc.GetBlockStorage(block.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.AttachedToInstance).To(Equal(instance.ID))
+
+	g.Expect(svc.DetachBlockStorage(block.ID)).To(Succeed())
+	g.Expect(svc.DeleteBlockStorage(block.ID)).To(Succeed())
+	got, err = svc.GetBlockStorage(block.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+	g.Expect(svc.DeleteBlockStorage(block.ID)).To(Succeed())
+
+	// Volumes below the minimum size of their type are rejected.
+	_, err = svc.CreateBlockStorage(machineScope.Name(), "ewr", infrav1.DataVolume{Label: "data", SizeGB: 10, Type: infrav1.VolumeTypeHDD})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTerminalError(err)).To(BeTrue())
+}
+
+func TestDataVolumeBootstrapCommands(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	machineScope.VultrMachine.Spec.DataVolumes = []infrav1.DataVolume{
+		{Label: "etcd", SizeGB: 10, Filesystem: "ext4", MountPath: "/var/lib/etcd"},
+		{Label: "raw", SizeGB: 10},
+	}
+	machineScope.VultrMachine.Status.DataVolumes = []infrav1.DataVolumeStatus{
+		{Label: "etcd", ID: "block-1", MountID: "ewr-etcd"},
+		{Label: "raw", ID: "block-2", MountID: "ewr-raw"},
+	}
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	userData, _ := s.InstanceUserData(instance.ID)
+	g.Expect(userData).To(ContainSubstring("mkfs -t 'ext4' '/dev/disk/by-id/virtio-ewr-etcd'"))
+	g.Expect(userData).To(ContainSubstring("echo '/dev/disk/by-id/virtio-ewr-etcd /var/lib/etcd ext4 defaults,nofail 0 2' >> /etc/fstab"))
+	g.Expect(userData).NotTo(ContainSubstring("virtio-ewr-raw"))
+
+	// The volumes are mounted before kubeadm runs.
+	g.Expect(userData).To(MatchRegexp(`(?s)virtio-ewr-etcd.*kubeadm init`))
+}
+
+func TestDataVolumeCommandsQuoteValues(t *testing.T) {
+	g := NewWithT(t)
+
+	// The API rejects such paths, the commands must not run them anyway.
+	mountPath := "/data'; touch injected; '"
+	commands := dataVolumeCommands(
+		[]infrav1.DataVolume{{Label: "data", SizeGB: 10, Filesystem: "ext4", MountPath: mountPath}},
+		[]infrav1.DataVolumeStatus{{Label: "data", MountID: "ewr-data"}},
+	)
+	g.Expect(commands).To(HaveLen(1))
+	g.Expect(commands[0]).To(ContainSubstring("mkdir -p " + shellQuote(mountPath)))
+	g.Expect(commands[0]).To(ContainSubstring("mount " + shellQuote(mountPath)))
+	g.Expect(commands[0]).NotTo(ContainSubstring("mkdir -p /data"))
+
+	// The shell sees the quoted value as a single word.
+	out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(mountPath)).Output()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).To(Equal(mountPath))
+}
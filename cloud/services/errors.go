@@ -0,0 +1,137 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// terminalMessages are fragments of Vultr API error messages which describe a
+// request that will keep failing no matter how often it is retried.
+var terminalMessages = []string{
+	"invalid plan",
+	"invalid region",
+	"invalid snapshot",
+	"invalid os",
+	"plan is not available",
+	"not available in",
+	"unavailable in",
+	"sold out",
+	"quota",
+	"limit reached",
+	"reached the maximum",
+	"maximum number of",
+}
+
+// APIError is an error returned by the Vultr API, classified as either
+// terminal or transient.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, or zero when no
+	// response was received.
+	StatusCode int
+	// Message is the error message reported by the Vultr API.
+	Message string
+	// Terminal is true when retrying the request cannot succeed without
+	// manual intervention, e.g. an invalid plan or an exceeded quota.
+	Terminal bool
+
+	err error
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// vultrErrorBody is the JSON body the Vultr API sends along with errors.
+type vultrErrorBody struct {
+	Error  string `json:"error"`
+	Status int    `json:"status"`
+}
+
+// classifyError wraps an error returned by a govultr call in an APIError.
+// Rate limiting, server side and network errors are transient, invalid
+// requests and exhausted quotas are terminal.
+func classifyError(resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	apiErr := &APIError{Message: err.Error(), err: err}
+
+	// govultr returns the raw response body as the error message.
+	var body vultrErrorBody
+	if json.Unmarshal([]byte(err.Error()), &body) == nil {
+		apiErr.Message = body.Error
+		apiErr.StatusCode = body.Status
+	}
+	if resp != nil {
+		apiErr.StatusCode = resp.StatusCode
+	}
+
+	switch code := apiErr.StatusCode; {
+	case code == 0, code == http.StatusTooManyRequests, code >= http.StatusInternalServerError:
+		// No response at all, throttled or a Vultr side failure. govultr has
+		// already given up retrying, the next reconcile may still succeed.
+		apiErr.Terminal = false
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		// Credential problems belong to the controller, not to the object.
+		apiErr.Terminal = false
+	case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+		apiErr.Terminal = true
+	default:
+		apiErr.Terminal = hasTerminalMessage(apiErr.Message)
+	}
+
+	return apiErr
+}
+
+func hasTerminalMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, m := range terminalMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminalError returns true if err, or any error it wraps, is a Vultr API
+// error which will not go away by retrying.
+func IsTerminalError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Terminal
+	}
+	return false
+}
+
+// IsUnauthorizedError returns true if err, or any error it wraps, is a Vultr
+// API error caused by an invalid or revoked API key.
+func IsUnauthorizedError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		err      error
+		terminal bool
+	}{
+		{
+			name:     "invalid plan",
+			status:   http.StatusBadRequest,
+			err:      errors.New(`{"error":"Invalid plan chosen.","status":400}`),
+			terminal: true,
+		},
+		{
+			name:     "plan not offered in region",
+			status:   http.StatusNotAcceptable,
+			err:      errors.New(`{"error":"Plan is not available in the selected region","status":406}`),
+			terminal: true,
+		},
+		{
+			name:     "quota exceeded",
+			status:   http.StatusPreconditionFailed,
+			err:      errors.New(`{"error":"You have reached the maximum number of instances","status":412}`),
+			terminal: true,
+		},
+		{
+			name:     "rate limited",
+			status:   http.StatusTooManyRequests,
+			err:      errors.New(`{"error":"Rate limit reached - please try your request again later.","status":429}`),
+			terminal: false,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusServiceUnavailable,
+			err:      errors.New(`{"error":"Service unavailable","status":503}`),
+			terminal: false,
+		},
+		{
+			name:     "unauthorized",
+			status:   http.StatusUnauthorized,
+			err:      errors.New(`{"error":"Invalid API token.","status":401}`),
+			terminal: false,
+		},
+		{
+			name:     "retries exhausted",
+			err:      errors.New(`gave up after 4 attempts, last error: "Rate limit reached"`),
+			terminal: false,
+		},
+		{
+			name:     "status from body only",
+			err:      errors.New(`{"error":"Invalid snapshot","status":400}`),
+			terminal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var resp *http.Response
+			if tt.status != 0 {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+
+			err := pkgerrors.Wrap(classifyError(resp, tt.err), "failed to create instance")
+			g.Expect(IsTerminalError(err)).To(Equal(tt.terminal))
+		})
+	}
+
+	g := NewWithT(t)
+	g.Expect(classifyError(nil, nil)).To(Succeed())
+	g.Expect(IsTerminalError(errors.New("plain error"))).To(BeFalse())
+}
+
+func TestIsUnauthorizedError(t *testing.T) {
+	g := NewWithT(t)
+
+	err := pkgerrors.Wrap(classifyError(nil, errors.New(`{"error":"Invalid API token.","status":401}`)), "failed to get instance")
+	g.Expect(IsUnauthorizedError(err)).To(BeTrue())
+
+	err = classifyError(&http.Response{StatusCode: http.StatusForbidden}, errors.New("forbidden"))
+	g.Expect(IsUnauthorizedError(err)).To(BeFalse())
+	g.Expect(IsUnauthorizedError(errors.New("plain error"))).To(BeFalse())
+}
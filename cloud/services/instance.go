@@ -20,72 +20,69 @@ import (
 	"context"
 	"encoding/base64"
 	"net/http"
+	"slices"
 	"strings"
-	"time"
 
-	"github.com/labstack/gommon/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
 	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
 	"github.com/vultr/cluster-api-provider-vultr/util"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 	"github.com/vultr/govultr/v3"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
-// GetInstance retrieves an instance by its ID.
-func (s *Service) GetInstance(instanceID string) (*govultr.Instance, error) {
+// loadBalancerLocks serializes the updates of the instances of a load
+// balancer, which replace the whole list of instances.
+var loadBalancerLocks = newKeyedMutex()
+
+// GetInstance retrieves an instance by its ID. It returns nil if the instance
+// does not exist.
+func (s *Service) GetInstance(instanceID string) (_ *govultr.Instance, reterr error) {
+	ctx, span := s.startSpan("GetInstance", attribute.String("instance.id", instanceID))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	if instanceID == "" {
 		s.scope.Info("VultrInstance does not have an instance id")
 		return nil, nil
 	}
 
-	s.scope.Logger.V(2).Info("Looking for instance by ID", "instance-id", instanceID)
+	s.scope.V(2).Info("Looking for instance by ID", logging.InstanceIDKey, instanceID)
 
-	instance, resp, err := s.scope.Instances.Get(s.ctx, instanceID)
+	instance, resp, err := s.scope.Instances.Get(ctx, instanceID)
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil, nil
 		}
-		if resp != nil && resp.StatusCode == http.StatusBadRequest {
-			return nil, nil
-		}
-		return nil, errors.Wrapf(err, "failed to get instance with ID %q", instanceID)
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get instance with ID %q", instanceID)
 	}
 
 	return instance, nil
 }
 
-func (s *Service) CreateInstance(scope *scope.MachineScope) (*govultr.Instance, error) {
-	s.scope.V(2).Info("Creating an instance for a machine")
+func (s *Service) CreateInstance(scope *scope.MachineScope) (_ *govultr.Instance, reterr error) {
+	ctx, span := s.startSpan("CreateInstance", attribute.String("machine", scope.Name()))
+	defer func() { tracing.EndSpan(span, reterr) }()
 
-	s.scope.V(2).Info("Retrieving bootstrap data")
-	bootstrapData, err := scope.GetBootstrapData()
-
-	commands := []string{
-		"ufw disable",
-	}
-	updatedBootstrapData := appendToUserDataCloudConfig(bootstrapData, commands)
-	encodedBootstrapData := base64.StdEncoding.EncodeToString([]byte(updatedBootstrapData))
+	log := s.scope.WithValues(logging.MachineKey, scope.Name())
+	log.V(2).Info("Creating an instance for a machine")
 
+	encodedBootstrapData, err := s.userData(ctx, scope)
 	if err != nil {
-		log.Error(err, "Error getting bootstrap data for machine")
-		return nil, errors.Wrap(err, "failed to retrieve bootstrap data")
+		return nil, err
 	}
-	s.scope.V(2).Info("Successfully retrieved bootstrap data")
 
-	var sshKeyIDs []string //nolint:prealloc
-	for _, sshKeyID := range scope.VultrMachine.Spec.SSHKey {
-		keys, err := s.GetSSHKey(sshKeyID)
-		if err != nil {
-			return nil, err
-		}
-		sshKeyIDs = append(sshKeyIDs, keys.ID)
+	sshKeyIDs, err := s.sshKeyIDs(ctx, scope)
+	if err != nil {
+		return nil, err
 	}
-	clusterName := s.scope.Name()
 	instanceName := scope.Name()
 
-	s.scope.V(2).Info("Preparing instance creation request payload")
+	log.V(2).Info("Preparing instance creation request payload")
 	instanceReq := &govultr.InstanceCreateReq{
 		Label:           instanceName,
 		Hostname:        instanceName,
@@ -94,7 +91,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope) (*govultr.Instance,
 		SSHKeys:         sshKeyIDs,
 		SnapshotID:      scope.VultrMachine.Spec.Snapshot,
 		UserData:        encodedBootstrapData,
-		EnableIPv6:      util.Pointer(true),
+		EnableIPv6:      util.Pointer(ptr.Deref(scope.VultrMachine.Spec.EnableIPv6, true)),
 		FirewallGroupID: scope.VultrMachine.Spec.FirewallGroupID,
 		VPCOnly:         util.Pointer(scope.VultrMachine.Spec.VPCOnly),
 	}
@@ -107,46 +104,219 @@ func (s *Service) CreateInstance(scope *scope.MachineScope) (*govultr.Instance,
 		instanceReq.AttachVPC2 = append(instanceReq.AttachVPC2, scope.VultrMachine.Spec.VPCID) //nolint:staticcheck
 	}
 
-	s.scope.V(2).Info("Building instance tags")
-	instanceReq.Tags = infrav1.BuildTags(infrav1.BuildTagParams{
-		ClusterName: clusterName,
-		ClusterUID:  s.scope.UID(),
-		Name:        instanceName,
-		Role:        scope.Role(),
-	})
-	s.scope.V(2).Info("Successfully built instance tags")
+	instanceReq.Tags = s.MachineTags(scope)
 
-	s.scope.V(2).Info("Creating instance with Vultr API")
-	instance, _, err := s.scope.Instances.Create(s.ctx, instanceReq)
+	log.V(2).Info("Creating instance with Vultr API")
+	instance, resp, err := s.scope.Instances.Create(ctx, instanceReq)
 	if err != nil {
 		log.Error(err, "Failed to create new instance")
-		return nil, errors.Wrap(err, "Failed to create new instance")
+		return nil, errors.Wrap(classifyError(resp, err), "Failed to create new instance")
 	}
-	s.scope.V(2).Info("Successfully created instance", "instance-id", instance.ID)
+	log.V(2).Info("Successfully created instance", logging.InstanceIDKey, instance.ID)
 
 	return instance, nil
 
 }
 
-func (s *Service) DeleteInstance(id string) error {
-	log.Info("Deleting instance resources")
-	s.scope.V(2).Info("Attempting to delete instance", "instance-id", id)
+func (s *Service) DeleteInstance(id string) (reterr error) {
+	ctx, span := s.startSpan("DeleteInstance", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	log := s.scope.WithValues(logging.InstanceIDKey, id)
+	log.V(2).Info("Attempting to delete instance")
 	if id == "" {
-		s.scope.Info("Instance does not have an instance id")
+		log.Info("Instance does not have an instance id")
 		return errors.New("cannot delete instance. instance does not have an instance id")
 	}
 
 	// Call the Delete method directly with the string id
-	if err := s.scope.Instances.Delete(s.ctx, id); err != nil {
-		return errors.Wrapf(err, "failed to delete instance with id %q", id)
+	if err := s.scope.Instances.Delete(ctx, id); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to delete instance with id %q", id)
 	}
 
-	s.scope.V(2).Info("Deleted instance", "instance-id", id)
+	log.V(2).Info("Deleted instance")
+	return nil
+}
+
+// StartInstance powers on a stopped instance.
+func (s *Service) StartInstance(id string) (reterr error) {
+	ctx, span := s.startSpan("StartInstance", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Starting instance", logging.InstanceIDKey, id)
+	if err := s.scope.Instances.Start(ctx, id); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to start instance with id %q", id)
+	}
 	return nil
 }
 
+// HaltInstance powers off an instance.
+func (s *Service) HaltInstance(id string) (reterr error) {
+	ctx, span := s.startSpan("HaltInstance", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Halting instance", logging.InstanceIDKey, id)
+	if err := s.scope.Instances.Halt(ctx, id); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to halt instance with id %q", id)
+	}
+	return nil
+}
+
+// RebootInstance reboots an instance.
+func (s *Service) RebootInstance(id string) (reterr error) {
+	ctx, span := s.startSpan("RebootInstance", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Rebooting instance", logging.InstanceIDKey, id)
+	if err := s.scope.Instances.Reboot(ctx, id); err != nil {
+		return errors.Wrapf(classifyError(nil, err), "failed to reboot instance with id %q", id)
+	}
+	return nil
+}
+
+// ReinstallInstance reinstalls an instance from the image of the machine with
+// its current bootstrap data. Instances created from a snapshot are restored
+// from it. All data on the instance is lost.
+func (s *Service) ReinstallInstance(scope *scope.MachineScope, id string) (reterr error) {
+	ctx, span := s.startSpan("ReinstallInstance", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	encodedBootstrapData, err := s.userData(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	// The user data runs again on the first boot of the reinstalled instance.
+	s.scope.V(2).Info("Updating instance user data", logging.InstanceIDKey, id)
+	if _, resp, err := s.scope.Instances.Update(ctx, id, &govultr.InstanceUpdateReq{UserData: encodedBootstrapData}); err != nil {
+		return errors.Wrapf(classifyError(resp, err), "failed to update user data of instance with id %q", id)
+	}
+
+	if snapshotID := scope.VultrMachine.Spec.Snapshot; snapshotID != "" {
+		s.scope.V(2).Info("Restoring instance from snapshot", logging.InstanceIDKey, id, "snapshot", snapshotID)
+		if resp, err := s.scope.Instances.Restore(ctx, id, &govultr.RestoreReq{SnapshotID: snapshotID}); err != nil {
+			return errors.Wrapf(classifyError(resp, err), "failed to restore instance with id %q from snapshot %q", id, snapshotID)
+		}
+		return nil
+	}
+
+	s.scope.V(2).Info("Reinstalling instance", logging.InstanceIDKey, id)
+	if _, resp, err := s.scope.Instances.Reinstall(ctx, id, &govultr.ReinstallReq{Hostname: scope.Name()}); err != nil {
+		return errors.Wrapf(classifyError(resp, err), "failed to reinstall instance with id %q", id)
+	}
+	return nil
+}
+
+// UpdateInstanceTags replaces the tags of an instance.
+func (s *Service) UpdateInstanceTags(id string, tags infrav1.Tags) (reterr error) {
+	ctx, span := s.startSpan("UpdateInstanceTags", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Updating instance tags", logging.InstanceIDKey, id, "tags", tags)
+	if _, resp, err := s.scope.Instances.Update(ctx, id, &govultr.InstanceUpdateReq{Tags: tags}); err != nil {
+		return errors.Wrapf(classifyError(resp, err), "failed to update tags of instance with id %q", id)
+	}
+	return nil
+}
+
+// MachineTags returns the tags of the instance or bare metal server of a
+// machine: the tags identifying the cluster, role and name of the machine,
+// followed by the additional tags of the cluster and the machine.
+func (s *Service) MachineTags(scope *scope.MachineScope) infrav1.Tags {
+	return infrav1.BuildTags(infrav1.BuildTagParams{
+		ClusterName: s.scope.Name(),
+		ClusterUID:  s.scope.UID(),
+		Name:        scope.Name(),
+		Role:        scope.Role(),
+		Additional:  scope.AdditionalTags(),
+	})
+}
+
+// MergeMachineTags returns the tags a server of a machine with the current
+// tags should have: the tags of MachineTags, followed by the current tags the
+// provider does not own. The provider owns the tags of BuildTags and the
+// additional tags it applied before, as recorded in the status.
+func (s *Service) MergeMachineTags(scope *scope.MachineScope, current []string) infrav1.Tags {
+	tags := s.MachineTags(scope)
+	applied := scope.VultrMachine.Status.AppliedAdditionalTags
+	for _, tag := range current {
+		if infrav1.IsProviderTag(tag) || slices.Contains(applied, tag) || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// GetPlanUpgrades returns the plans an instance can be upgraded to.
+func (s *Service) GetPlanUpgrades(id string) (_ []string, reterr error) {
+	ctx, span := s.startSpan("GetPlanUpgrades", attribute.String("instance.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	upgrades, resp, err := s.scope.Instances.GetUpgrades(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get upgrades of instance with id %q", id)
+	}
+	if upgrades == nil {
+		return nil, nil
+	}
+	return upgrades.Plans, nil
+}
+
+// UpgradeInstancePlan resizes an instance to a larger plan.
+func (s *Service) UpgradeInstancePlan(id, plan string) (reterr error) {
+	ctx, span := s.startSpan("UpgradeInstancePlan", attribute.String("instance.id", id), attribute.String("plan", plan))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Upgrading instance plan", logging.InstanceIDKey, id, "plan", plan)
+	if _, resp, err := s.scope.Instances.Update(ctx, id, &govultr.InstanceUpdateReq{Plan: plan}); err != nil {
+		return errors.Wrapf(classifyError(resp, err), "failed to upgrade instance with id %q to plan %q", id, plan)
+	}
+	return nil
+}
+
+// userData returns the base64 encoded bootstrap data of the machine, with the
+// commands the provider runs before it.
+func (s *Service) userData(ctx context.Context, scope *scope.MachineScope) (string, error) {
+	log := s.scope.WithValues(logging.MachineKey, scope.Name())
+
+	log.V(2).Info("Retrieving bootstrap data")
+	bootstrapData, err := scope.GetBootstrapData(ctx)
+	if err != nil {
+		log.Error(err, "Error getting bootstrap data for machine")
+		return "", errors.Wrap(err, "failed to retrieve bootstrap data")
+	}
+	log.V(2).Info("Successfully retrieved bootstrap data")
+
+	commands := []string{
+		"ufw disable",
+	}
+	commands = append(commands, dataVolumeCommands(scope.VultrMachine.Spec.DataVolumes, scope.VultrMachine.Status.DataVolumes)...)
+	updatedBootstrapData := appendToUserDataCloudConfig(bootstrapData, commands)
+	return base64.StdEncoding.EncodeToString([]byte(updatedBootstrapData)), nil
+}
+
+// sshKeyIDs returns the IDs of the SSH keys of the machine.
+func (s *Service) sshKeyIDs(ctx context.Context, scope *scope.MachineScope) ([]string, error) {
+	var sshKeyIDs []string //nolint:prealloc
+	for _, sshKeyID := range scope.VultrMachine.Spec.SSHKey {
+		keys, err := s.getSSHKey(ctx, sshKeyID)
+		if err != nil {
+			return nil, err
+		}
+		sshKeyIDs = append(sshKeyIDs, keys.ID)
+	}
+	return sshKeyIDs, nil
+}
+
 // GetInstanceAddress converts Vultr instance IPs to corev1.NodeAddresses.
 func (s *Service) GetInstanceAddress(instance *govultr.Instance) ([]corev1.NodeAddress, error) {
+	return s.nodeAddresses(instance), nil
+}
+
+// nodeAddresses converts the IPs and hostname of an instance to
+// corev1.NodeAddresses.
+func (s *Service) nodeAddresses(instance *govultr.Instance) []corev1.NodeAddress {
 	addresses := []corev1.NodeAddress{}
 
 	// Add private IPv4 address
@@ -156,7 +326,7 @@ func (s *Service) GetInstanceAddress(instance *govultr.Instance) ([]corev1.NodeA
 			Address: instance.InternalIP,
 		})
 	} else {
-		s.scope.Info("No internal IPv4 address found for the instance", "instance-id", instance.ID)
+		s.scope.Info("No internal IPv4 address found for the instance", logging.InstanceIDKey, instance.ID)
 	}
 
 	// Add public IPv4 address
@@ -166,28 +336,63 @@ func (s *Service) GetInstanceAddress(instance *govultr.Instance) ([]corev1.NodeA
 			Address: instance.MainIP,
 		})
 	} else {
-		s.scope.Info("No external IPv4 address found for the instance", "instance-id", instance.ID)
+		s.scope.Info("No external IPv4 address found for the instance", logging.InstanceIDKey, instance.ID)
+	}
+
+	// Add public IPv6 address
+	if instance.V6MainIP != "" {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeExternalIP,
+			Address: instance.V6MainIP,
+		})
 	}
 
-	return addresses, nil
+	if instance.Hostname != "" {
+		addresses = append(addresses,
+			corev1.NodeAddress{
+				Type:    corev1.NodeHostName,
+				Address: instance.Hostname,
+			},
+			corev1.NodeAddress{
+				Type:    corev1.NodeInternalDNS,
+				Address: instance.Hostname,
+			},
+		)
+	}
+
+	return addresses
 }
 
-func (s *Service) AddInstanceToVLB(vlbID, instanceID string) error {
-	for {
-		currentVlb, _, err := s.scope.LoadBalancers.Get(context.TODO(), vlbID)
-		if err != nil {
-			return err
-		}
+// AddInstanceToVLB adds an instance to a load balancer. It reports false
+// without an error while the load balancer is not active yet, the caller
+// should try again later.
+func (s *Service) AddInstanceToVLB(vlbID, instanceID string) (_ bool, reterr error) {
+	ctx, span := s.startSpan("AddInstanceToVLB", attribute.String("loadbalancer.id", vlbID), attribute.String("instance.id", instanceID))
+	defer func() { tracing.EndSpan(span, reterr) }()
 
-		if currentVlb.Status != "active" {
-			time.Sleep(10 * time.Second)
-		} else {
-			updateReq := govultr.LoadBalancerReq{}
-			updateReq.Instances = append(currentVlb.Instances, instanceID)
-			err := s.scope.LoadBalancers.Update(context.TODO(), vlbID, &updateReq)
-			return err
-		}
+	// Concurrent reconciles of control plane machines would otherwise
+	// overwrite each other's instance.
+	unlock := loadBalancerLocks.lock(vlbID)
+	defer unlock()
+
+	currentVlb, resp, err := s.scope.LoadBalancers.Get(ctx, vlbID)
+	if err != nil {
+		return false, classifyError(resp, err)
+	}
+	if slices.Contains(currentVlb.Instances, instanceID) {
+		return true, nil
+	}
+	if currentVlb.Status != "active" {
+		s.scope.V(2).Info("Waiting for load balancer to become active", logging.LoadBalancerIDKey, vlbID, logging.InstanceIDKey, instanceID)
+		return false, nil
+	}
+
+	updateReq := govultr.LoadBalancerReq{}
+	updateReq.Instances = append(currentVlb.Instances, instanceID)
+	if err := s.scope.LoadBalancers.Update(ctx, vlbID, &updateReq); err != nil {
+		return false, classifyError(nil, err)
 	}
+	return true, nil
 }
 
 func appendToUserDataCloudConfig(userData string, commands []string) string {
@@ -0,0 +1,411 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+)
+
+const testBootstrapData = "#cloud-config\nruncmd:\n  - kubeadm init\n"
+
+// newTestScopes returns cluster and machine scopes for a control plane
+// machine talking to the fake Vultr API s.
+func newTestScopes(t *testing.T, s *vultrfake.Server) (*scope.ClusterScope, *scope.MachineScope) {
+	t.Helper()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "cluster-uid"}}
+	vultrCluster := &infrav1.VultrCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "vultrcluster-uid"},
+		Spec:       infrav1.VultrClusterSpec{Region: "ewr"},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-control-plane-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test",
+			Bootstrap:   clusterv1.Bootstrap{DataSecretName: ptr.To("bootstrap")},
+		},
+	}
+	vultrMachine := &infrav1.VultrMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-control-plane-abcde", Namespace: "default"},
+		Spec:       infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: "default"},
+		Data:       map[string][]byte{"value": []byte(testBootstrapData)},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(cluster, vultrCluster, machine, vultrMachine, secret).
+		WithStatusSubresource(vultrCluster, vultrMachine).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		VultrAPIClients: scope.NewVultrAPIClients(s.VultrClient()),
+		Client:          c,
+		Logger:          log.Log,
+		Cluster:         cluster,
+		VultrCluster:    vultrCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       c,
+		Logger:       log.Log,
+		Cluster:      cluster,
+		Machine:      machine,
+		VultrCluster: vultrCluster,
+		VultrMachine: vultrMachine,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return clusterScope, machineScope
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.Region).To(Equal("ewr"))
+	g.Expect(instance.Plan).To(Equal("vc2-2c-4gb"))
+	g.Expect(instance.Tags).To(ContainElement(infrav1.ClusterNameUIDRoleTag("test", clusterScope.UID(), infrav1.APIServerRoleTagValue)))
+
+	userData, ok := s.InstanceUserData(instance.ID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(userData).To(ContainSubstring("ufw disable"))
+	g.Expect(userData).To(ContainSubstring("kubeadm init"))
+
+	got, err := svc.GetInstance(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.ID).To(Equal(instance.ID))
+
+	addrs, err := svc.GetInstanceAddress(got)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(ContainElement(corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: got.MainIP}))
+
+	// Only a 404 means that the instance is gone.
+	s.InjectFault(vultrfake.Fault{Method: http.MethodGet, Path: "/v2/instances/" + instance.ID, StatusCode: http.StatusBadRequest, Times: 1})
+	got, err = svc.GetInstance(instance.ID)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(got).To(BeNil())
+
+	g.Expect(svc.DeleteInstance(instance.ID)).To(Succeed())
+	got, err = svc.GetInstance(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
+
+func TestGetInstanceAddress(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.V6MainIP).NotTo(BeEmpty())
+
+	addrs, err := svc.GetInstanceAddress(instance)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(ConsistOf(
+		corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: instance.MainIP},
+		corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: instance.V6MainIP},
+		corev1.NodeAddress{Type: corev1.NodeHostName, Address: "test-control-plane-abcde"},
+		corev1.NodeAddress{Type: corev1.NodeInternalDNS, Address: "test-control-plane-abcde"},
+	))
+
+	// IPv6 can be disabled per machine.
+	machineScope.VultrMachine.Spec.EnableIPv6 = ptr.To(false)
+	instance, err = svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.V6MainIP).To(BeEmpty())
+
+	addrs, err = svc.GetInstanceAddress(instance)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(ConsistOf(
+		corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: instance.MainIP},
+		corev1.NodeAddress{Type: corev1.NodeHostName, Address: "test-control-plane-abcde"},
+		corev1.NodeAddress{Type: corev1.NodeInternalDNS, Address: "test-control-plane-abcde"},
+	))
+}
+
+func TestStartInstance(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.SetInstanceStatus(instance.ID, "active", "stopped")).To(BeTrue())
+
+	g.Expect(svc.StartInstance(instance.ID)).To(Succeed())
+	got, _ := s.Instance(instance.ID)
+	g.Expect(got.PowerStatus).To(Equal("running"))
+
+	g.Expect(svc.StartInstance("missing")).NotTo(Succeed())
+}
+
+func TestInstanceActions(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(svc.HaltInstance(instance.ID)).To(Succeed())
+	got, _ := s.Instance(instance.ID)
+	g.Expect(got.PowerStatus).To(Equal("stopped"))
+
+	g.Expect(svc.RebootInstance(instance.ID)).To(Succeed())
+	got, _ = s.Instance(instance.ID)
+	g.Expect(got.PowerStatus).To(Equal("running"))
+
+	// Reinstalls send the current bootstrap data along.
+	machineScope.VultrMachine.Spec.DataVolumes = []infrav1.DataVolume{{Label: "data", SizeGB: 10, Filesystem: "ext4"}}
+	machineScope.VultrMachine.Status.DataVolumes = []infrav1.DataVolumeStatus{{Label: "data", MountID: "ewr-data"}}
+	s.SetInstanceProvisionDelay(time.Hour)
+	g.Expect(svc.ReinstallInstance(machineScope, instance.ID)).To(Succeed())
+	got, _ = s.Instance(instance.ID)
+	g.Expect(got.Status).To(Equal("pending"))
+	g.Expect(got.Hostname).To(Equal(machineScope.Name()))
+	userData, _ := s.InstanceUserData(instance.ID)
+	g.Expect(userData).To(ContainSubstring("kubeadm init"))
+	g.Expect(userData).To(ContainSubstring("virtio-ewr-data"))
+	g.Expect(s.Requests(http.MethodPost, "/v2/instances/"+instance.ID+"/reinstall")).To(Equal(1))
+
+	// Instances created from a snapshot are restored from it.
+	snapshotID := s.AddSnapshot(govultr.Snapshot{})
+	machineScope.VultrMachine.Spec.Snapshot = snapshotID
+	g.Expect(svc.ReinstallInstance(machineScope, instance.ID)).To(Succeed())
+	got, _ = s.Instance(instance.ID)
+	g.Expect(got.SnapshotID).To(Equal(snapshotID))
+	g.Expect(s.Requests(http.MethodPost, "/v2/instances/"+instance.ID+"/restore")).To(Equal(1))
+	g.Expect(s.Requests(http.MethodPost, "/v2/instances/"+instance.ID+"/reinstall")).To(Equal(1))
+	machineScope.VultrMachine.Spec.Snapshot = "missing"
+	g.Expect(svc.ReinstallInstance(machineScope, instance.ID)).NotTo(Succeed())
+
+	g.Expect(svc.RebootInstance("missing")).NotTo(Succeed())
+}
+
+func TestUpgradeInstancePlan(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	upgrades, err := svc.GetPlanUpgrades(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrades).To(ContainElement("vc2-4c-8gb"))
+	g.Expect(upgrades).NotTo(ContainElement("vc2-1c-2gb"))
+
+	g.Expect(svc.UpgradeInstancePlan(instance.ID, "vc2-4c-8gb")).To(Succeed())
+	got, err := svc.GetInstance(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Plan).To(Equal("vc2-4c-8gb"))
+
+	// Downgrades are rejected by the Vultr API.
+	err = svc.UpgradeInstancePlan(instance.ID, "vc2-2c-4gb")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTerminalError(err)).To(BeTrue())
+}
+
+func TestInstanceTags(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	machineScope.VultrCluster.Spec.AdditionalTags = []string{"cost-center:42", "env:prod"}
+	machineScope.VultrMachine.Spec.AdditionalTags = []string{"env:prod", "team:platform"}
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.Tags).To(Equal([]string{
+		infrav1.ClusterNameTag("test"),
+		infrav1.ClusterNameRoleTag("test", infrav1.APIServerRoleTagValue),
+		infrav1.ClusterNameUIDRoleTag("test", "cluster-uid", infrav1.APIServerRoleTagValue),
+		infrav1.NameTagFromName("test-control-plane-abcde"),
+		"cost-center:42", "env:prod", "team:platform",
+	}))
+	g.Expect(svc.MachineTags(machineScope).Equal(instance.Tags)).To(BeTrue())
+
+	// Tags the provider does not own are kept, removed additional tags are
+	// only dropped when they were applied before.
+	machineScope.SetAppliedAdditionalTags(machineScope.AdditionalTags())
+	machineScope.VultrMachine.Spec.AdditionalTags = nil
+	current := append(slices.Clone(instance.Tags), "billing:console", infrav1.NameTagFromName("renamed"))
+	tags := svc.MergeMachineTags(machineScope, current)
+	g.Expect(tags.Equal(current)).To(BeFalse())
+	g.Expect(tags).To(ContainElements("cost-center:42", "env:prod", "billing:console"))
+	g.Expect(tags).NotTo(ContainElements("team:platform", infrav1.NameTagFromName("renamed")))
+	g.Expect(svc.UpdateInstanceTags(instance.ID, tags)).To(Succeed())
+	got, err := svc.GetInstance(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tags.Equal(got.Tags)).To(BeTrue())
+	g.Expect(svc.MergeMachineTags(machineScope, got.Tags).Equal(got.Tags)).To(BeTrue())
+}
+
+func TestCreateInstanceErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	// A single rate limited request is retried by govultr.
+	s.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/instances", StatusCode: http.StatusTooManyRequests, Times: 1})
+	_, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Persistent server errors are transient.
+	s.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/instances", StatusCode: http.StatusInternalServerError})
+	_, err = svc.CreateInstance(machineScope)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTerminalError(err)).To(BeFalse())
+	s.ClearFaults()
+
+	// Invalid requests are terminal.
+	machineScope.VultrMachine.Spec.PlanID = ""
+	_, err = svc.CreateInstance(machineScope)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(IsTerminalError(err)).To(BeTrue())
+	g.Expect(s.Instances()).To(HaveLen(1))
+}
+
+func TestAddInstanceToVLB(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{LoadBalancerProvisionDelay: 200 * time.Millisecond})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	spec := clusterScope.APIServerLoadbalancers()
+	spec.ApplyDefaults()
+	lb, err := svc.CreateLoadBalancer(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lb.Status).To(Equal("pending"))
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Pending load balancers are not waited for.
+	attached, err := svc.AddInstanceToVLB(lb.ID, instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(attached).To(BeFalse())
+	got, _ := s.LoadBalancer(lb.ID)
+	g.Expect(got.Instances).To(BeEmpty())
+
+	// The instance is attached once the load balancer became active.
+	g.Eventually(func() (bool, error) {
+		return svc.AddInstanceToVLB(lb.ID, instance.ID)
+	}).Should(BeTrue())
+	got, _ = s.LoadBalancer(lb.ID)
+	g.Expect(got.Instances).To(ConsistOf(instance.ID))
+
+	// Attaching an attached instance is a no-op.
+	updates := s.Requests(http.MethodPatch, "/v2/load-balancers")
+	g.Expect(svc.AddInstanceToVLB(lb.ID, instance.ID)).To(BeTrue())
+	g.Expect(s.Requests(http.MethodPatch, "/v2/load-balancers")).To(Equal(updates))
+}
+
+func TestAddInstanceToVLBConcurrently(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	spec := clusterScope.APIServerLoadbalancers()
+	spec.ApplyDefaults()
+	lb, err := svc.CreateLoadBalancer(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var ids []string
+	for range 5 {
+		instance, err := svc.CreateInstance(machineScope)
+		g.Expect(err).NotTo(HaveOccurred())
+		ids = append(ids, instance.ID)
+	}
+
+	// Concurrent reconciles of control plane machines must not overwrite
+	// each other's instance.
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attached, err := NewService(context.Background(), clusterScope).AddInstanceToVLB(lb.ID, id)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(attached).To(BeTrue())
+		}()
+	}
+	wg.Wait()
+	got, _ := s.LoadBalancer(lb.ID)
+	g.Expect(got.Instances).To(ConsistOf(ids))
+	g.Expect(loadBalancerLocks.locks).To(BeEmpty())
+}
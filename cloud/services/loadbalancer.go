@@ -17,25 +17,42 @@ limitations under the License.
 package services
 
 import (
-	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"net/http"
+
 	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 )
 
-func (s *Service) GetLoadBalancer(id string) (*govultr.LoadBalancer, error) {
+// GetLoadBalancer returns the load balancer with the given ID. It returns nil
+// if the load balancer does not exist.
+func (s *Service) GetLoadBalancer(id string) (_ *govultr.LoadBalancer, reterr error) {
+	ctx, span := s.startSpan("GetLoadBalancer", attribute.String("loadbalancer.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	if id == "" {
 		return nil, nil
 	}
 
-	lb, _, err := s.scope.LoadBalancers.Get(s.ctx, id)
+	lb, resp, err := s.scope.LoadBalancers.Get(ctx, id)
 	if err != nil {
-		return nil, err
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, classifyError(resp, err)
 	}
 
 	return lb, nil
 }
 
 // CreateLoadBalancer creates a new load balancer.
-func (s *Service) CreateLoadBalancer(spec *infrav1.VultrLoadBalancer) (*govultr.LoadBalancer, error) {
+func (s *Service) CreateLoadBalancer(spec *infrav1.VultrLoadBalancer) (_ *govultr.LoadBalancer, reterr error) {
+	ctx, span := s.startSpan("CreateLoadBalancer")
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	name := s.scope.Name() + "-" + s.scope.UID()
 	createReq := &govultr.LoadBalancerReq{
 		Label:  name,
@@ -72,19 +89,24 @@ func (s *Service) CreateLoadBalancer(spec *infrav1.VultrLoadBalancer) (*govultr.
 		createReq.FirewallRules = fwRules
 	}
 
-	lb, _, err := s.scope.LoadBalancers.Create(s.ctx, createReq)
+	lb, resp, err := s.scope.LoadBalancers.Create(ctx, createReq)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(resp, err)
 	}
+	s.scope.V(2).Info("Created load balancer", logging.LoadBalancerIDKey, lb.ID)
 
 	return lb, nil
 }
 
 // DeleteLoadBalancer deletes a load balancer by its ID.
-func (s *Service) DeleteLoadBalancer(id string) error {
-	if err := s.scope.LoadBalancers.Delete(s.ctx, id); err != nil {
-		return err
+func (s *Service) DeleteLoadBalancer(id string) (reterr error) {
+	ctx, span := s.startSpan("DeleteLoadBalancer", attribute.String("loadbalancer.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	if err := s.scope.LoadBalancers.Delete(ctx, id); err != nil {
+		return classifyError(nil, err)
 	}
+	s.scope.V(2).Info("Deleted load balancer", logging.LoadBalancerIDKey, id)
 
 	return nil
 }
@@ -0,0 +1,58 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+func TestGetLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, _ := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	spec := clusterScope.APIServerLoadbalancers()
+	spec.ApplyDefaults()
+	lb, err := svc.CreateLoadBalancer(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	got, err := svc.GetLoadBalancer(lb.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).NotTo(BeNil())
+	g.Expect(got.ID).To(Equal(lb.ID))
+
+	// Load balancers deleted outside of Cluster API are absent, not errors.
+	g.Expect(svc.DeleteLoadBalancer(lb.ID)).To(Succeed())
+	got, err = svc.GetLoadBalancer(lb.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeNil())
+
+	// Other failures are still reported.
+	lb, err = svc.CreateLoadBalancer(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	s.InjectFault(vultrfake.Fault{Method: http.MethodGet, Path: "/v2/load-balancers/" + lb.ID, StatusCode: http.StatusBadRequest})
+	_, err = svc.GetLoadBalancer(lb.ID)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// GetPlanCapacity returns the capacity of a node created from the machine
+// template, looked up from its plan, and the GPU type of GPU plans. It returns
+// nil if the plan does not exist.
+//
+// The Vultr API reports the GPU type and total GPU memory of a plan, but not
+// how many GPUs the node gets, so GPUs are not part of the capacity and GPU
+// plans cannot scale from zero without annotations.
+func (s *TemplateService) GetPlanCapacity() (_ corev1.ResourceList, gpuType string, reterr error) {
+	spec := s.scope.VultrMachineTemplate.Spec.Template.Spec
+	ctx, span := s.startSpan("GetPlanCapacity", attribute.String("plan", spec.PlanID))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	s.scope.V(2).Info("Looking up plan capacity", "plan", spec.PlanID)
+	opts := &govultr.ListOptions{PerPage: 500}
+	if spec.MachineType == infrav1.MachineTypeBareMetal {
+		for {
+			plans, meta, resp, err := s.scope.Plans.ListBareMetal(ctx, opts)
+			if err != nil {
+				return nil, "", errors.Wrap(classifyError(resp, err), "failed to list bare metal plans")
+			}
+			for _, plan := range plans {
+				if plan.ID == spec.PlanID {
+					return capacity(plan.CPUThreads, plan.RAM, plan.Disk), "", nil
+				}
+			}
+			if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+				return nil, "", nil
+			}
+			opts.Cursor = meta.Links.Next
+		}
+	}
+
+	for {
+		plans, meta, resp, err := s.scope.Plans.List(ctx, "", opts)
+		if err != nil {
+			return nil, "", errors.Wrap(classifyError(resp, err), "failed to list plans")
+		}
+		for _, plan := range plans {
+			if plan.ID == spec.PlanID {
+				return capacity(plan.VCPUCount, plan.RAM, plan.Disk), plan.GPUType, nil
+			}
+		}
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return nil, "", nil
+		}
+		opts.Cursor = meta.Links.Next
+	}
+}
+
+// capacity converts the size of a plan, with ramMB of memory and diskGB of
+// boot disk, to a ResourceList.
+func capacity(cpus, ramMB, diskGB int) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:              *resource.NewQuantity(int64(cpus), resource.DecimalSI),
+		corev1.ResourceMemory:           *resource.NewQuantity(int64(ramMB)*1024*1024, resource.BinarySI),
+		corev1.ResourceEphemeralStorage: *resource.NewQuantity(int64(diskGB)*1024*1024*1024, resource.BinarySI),
+	}
+}
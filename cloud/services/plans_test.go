@@ -0,0 +1,93 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+)
+
+func TestGetPlanCapacity(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	// capacityOf returns the capacity of a template with the plan, formatted
+	// to compare quantities regardless of their cached string.
+	capacityOf := func(machineType infrav1.MachineType, planID string) (map[corev1.ResourceName]string, string) {
+		template := &infrav1.VultrMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: infrav1.VultrMachineTemplateSpec{Template: infrav1.VultrMachineTemplateResource{
+				Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: planID, MachineType: machineType},
+			}},
+		}
+		templateScope, err := scope.NewMachineTemplateScope(scope.MachineTemplateScopeParams{
+			VultrAPIClients:      scope.NewVultrAPIClients(s.VultrClient()),
+			Client:               fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build(),
+			Logger:               log.Log,
+			VultrMachineTemplate: template,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		capacity, gpuType, err := NewTemplateService(context.Background(), templateScope).GetPlanCapacity()
+		g.Expect(err).NotTo(HaveOccurred())
+		if capacity == nil {
+			return nil, gpuType
+		}
+		quantities := map[corev1.ResourceName]string{}
+		for name, quantity := range capacity {
+			quantities[name] = quantity.String()
+		}
+		return quantities, gpuType
+	}
+
+	standard, gpuType := capacityOf("", "vc2-2c-4gb")
+	g.Expect(standard).To(Equal(map[corev1.ResourceName]string{
+		corev1.ResourceCPU:              "2",
+		corev1.ResourceMemory:           "4Gi",
+		corev1.ResourceEphemeralStorage: "80Gi",
+	}))
+	g.Expect(gpuType).To(BeEmpty())
+
+	// GPU plans report their GPU type, the controller warns that the number
+	// of GPUs is not part of the capacity.
+	gpu, gpuType := capacityOf(infrav1.MachineTypeInstance, "vcg-a16-6c-64g-16vram")
+	g.Expect(gpu).To(HaveKeyWithValue(corev1.ResourceCPU, "6"))
+	g.Expect(gpuType).To(Equal("NVIDIA_A16"))
+
+	// Bare metal servers report their CPU threads.
+	metal, _ := capacityOf(infrav1.MachineTypeBareMetal, "vbm-4c-32gb")
+	g.Expect(metal).To(HaveKeyWithValue(corev1.ResourceCPU, "8"))
+	g.Expect(metal).To(HaveKeyWithValue(corev1.ResourceMemory, "32Gi"))
+
+	unknown, _ := capacityOf("", "vc2-unknown")
+	g.Expect(unknown).To(BeNil())
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// GetAvailablePlans returns the plans of all types which are currently
+// offered in a region. It returns nil when the region does not exist.
+func (s *Service) GetAvailablePlans(region string) (_ []string, reterr error) {
+	ctx, span := s.startSpan("GetAvailablePlans", attribute.String("region", region))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	availability, resp, err := s.scope.Regions.Availability(ctx, region, "")
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get plan availability of region %q", region)
+	}
+	if availability.AvailablePlans == nil {
+		return []string{}, nil
+	}
+	return availability.AvailablePlans, nil
+}
@@ -19,7 +19,11 @@ package services
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 )
 
 // Service holds a collection of interfaces.
@@ -35,3 +39,32 @@ func NewService(ctx context.Context, scope *scope.ClusterScope) *Service {
 		ctx:   ctx,
 	}
 }
+
+// startSpan starts a span for the Service method name as a child of the
+// reconcile span, the returned context must be used for Vultr API calls.
+func (s *Service) startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("cluster", s.scope.Name()))
+	return tracing.StartSpan(s.ctx, "Service."+name, attrs...)
+}
+
+// TemplateService holds the services of machine templates, which do not
+// necessarily belong to a cluster.
+type TemplateService struct {
+	scope *scope.MachineTemplateScope
+	ctx   context.Context
+}
+
+// NewTemplateService returns a new service for a machine template.
+func NewTemplateService(ctx context.Context, scope *scope.MachineTemplateScope) *TemplateService {
+	return &TemplateService{
+		scope: scope,
+		ctx:   ctx,
+	}
+}
+
+// startSpan starts a span for the TemplateService method name as a child of
+// the reconcile span, the returned context must be used for Vultr API calls.
+func (s *TemplateService) startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("template", s.scope.Name()))
+	return tracing.StartSpan(s.ctx, "TemplateService."+name, attrs...)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+func TestServiceSpans(t *testing.T) {
+	g := NewWithT(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, machineScope := newTestScopes(t, s)
+
+	ctx, reconcile := provider.Tracer("test").Start(context.Background(), "Reconcile")
+	svc := NewService(ctx, clusterScope)
+
+	instance, err := svc.CreateInstance(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	exporter.Reset()
+
+	_, err = svc.GetInstance(instance.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	s.InjectFault(vultrfake.Fault{Method: http.MethodGet, Path: "/v2/instances/" + instance.ID, StatusCode: http.StatusBadRequest, Times: 1})
+	_, err = svc.GetInstance(instance.ID)
+	g.Expect(err).To(HaveOccurred())
+	reconcile.End()
+
+	spans := exporter.GetSpans()
+	g.Expect(spans).To(HaveLen(3))
+	for _, span := range spans[:2] {
+		g.Expect(span.Name).To(Equal("Service.GetInstance"))
+		g.Expect(span.Parent.SpanID()).To(Equal(reconcile.SpanContext().SpanID()))
+		g.Expect(span.Attributes).To(ContainElements(
+			attribute.String("instance.id", instance.ID),
+			attribute.String("cluster", "test"),
+		))
+	}
+	g.Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+	g.Expect(spans[1].Status.Code).To(Equal(codes.Error))
+	g.Expect(spans[1].Status.Description).NotTo(BeEmpty())
+	g.Expect(spans[1].Events).To(ContainElement(HaveField("Name", "exception")))
+}
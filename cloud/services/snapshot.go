@@ -0,0 +1,52 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// SnapshotStatusComplete is the status of snapshots instances can be created
+// from.
+const SnapshotStatusComplete = "complete"
+
+// GetSnapshot retrieves a snapshot by its ID. It returns nil when the snapshot
+// does not exist.
+func (s *Service) GetSnapshot(id string) (_ *govultr.Snapshot, reterr error) {
+	ctx, span := s.startSpan("GetSnapshot", attribute.String("snapshot.id", id))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	if id == "" {
+		return nil, nil
+	}
+
+	snapshot, resp, err := s.scope.Snapshots.Get(ctx, id)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(classifyError(resp, err), "failed to get snapshot with ID %q", id)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+func TestGetSnapshot(t *testing.T) {
+	g := NewWithT(t)
+
+	s := vultrfake.NewServer(vultrfake.Options{})
+	defer s.Close()
+	clusterScope, _ := newTestScopes(t, s)
+	svc := NewService(context.Background(), clusterScope)
+
+	id := s.AddSnapshot(govultr.Snapshot{Description: "kube"})
+	snapshot, err := svc.GetSnapshot(id)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.Status).To(Equal(SnapshotStatusComplete))
+
+	snapshot, err = svc.GetSnapshot("missing")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot).To(BeNil())
+}
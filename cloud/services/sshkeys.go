@@ -1,24 +1,37 @@
 package services
 
 import (
+	"context"
 	"errors"
 
 	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 )
 
 // GetSSHKey returns the SSH key from Vultr
-func (s *Service) GetSSHKey(sshkey string) (*govultr.SSHKey, error) {
+func (s *Service) GetSSHKey(sshkey string) (_ *govultr.SSHKey, reterr error) {
+	ctx, span := s.startSpan("GetSSHKey", attribute.String("sshkey.id", sshkey))
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	return s.getSSHKey(ctx, sshkey)
+}
+
+func (s *Service) getSSHKey(ctx context.Context, sshkey string) (*govultr.SSHKey, error) {
 	if sshkey == "" {
 		return nil, errors.New("missing ssh key")
 	}
 
-	s.scope.V(2).Info("fetching SSH key", "sshkey_id", sshkey)
-	key, _, err := s.scope.SSHKeys.Get(s.ctx, sshkey)
+	log := s.scope.WithValues(logging.SSHKeyIDKey, sshkey)
+	log.V(2).Info("fetching SSH key")
+	key, _, err := s.scope.SSHKeys.Get(ctx, sshkey)
 	if err != nil {
-		s.scope.V(2).Info("error fetching SSH key", "sshkey_id", sshkey, "error", err)
+		log.V(2).Info("error fetching SSH key", "error", err)
 		return nil, err
 	}
 
-	s.scope.V(2).Info("successfully fetched SSH key", "sshkey_id", sshkey)
+	log.V(2).Info("successfully fetched SSH key")
 	return key, nil
 }
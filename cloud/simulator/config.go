@@ -0,0 +1,180 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"net/netip"
+	"os"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Config describes the simulated Vultr API.
+type Config struct {
+	// Latency is added to every API request.
+	Latency metav1.Duration `json:"latency,omitempty"`
+	// RateLimit limits the API requests like the Vultr API does.
+	RateLimit RateLimit `json:"rateLimit,omitempty"`
+	// Instances configures how instances are provisioned.
+	Instances Provisioning `json:"instances,omitempty"`
+	// LoadBalancers configures how load balancers are provisioned.
+	LoadBalancers Provisioning `json:"loadBalancers,omitempty"`
+	// Network configures the addresses of instances.
+	Network Network `json:"network,omitempty"`
+	// VPCs are the VPCs which exist in the simulator.
+	VPCs []VPC `json:"vpcs,omitempty"`
+	// FirewallGroups are the firewall groups which exist in the simulator.
+	FirewallGroups []FirewallGroup `json:"firewallGroups,omitempty"`
+	// SSHKeys are the SSH keys which exist in the simulator.
+	SSHKeys []SSHKey `json:"sshKeys,omitempty"`
+	// Snapshots are the snapshots which exist in the simulator.
+	Snapshots []Snapshot `json:"snapshots,omitempty"`
+	// Faults make matching API requests fail or respond slowly.
+	Faults []Fault `json:"faults,omitempty"`
+}
+
+// RateLimit limits the number of API requests.
+type RateLimit struct {
+	// QPS is the number of requests per second handled before requests are
+	// rejected with 429 Too Many Requests. Requests are not limited when
+	// zero.
+	QPS float64 `json:"qps,omitempty"`
+	// Burst is the number of requests handled in a single burst.
+	Burst int `json:"burst,omitempty"`
+}
+
+// Provisioning configures how resources are provisioned.
+type Provisioning struct {
+	// Delay is how long resources stay pending after they were created.
+	Delay metav1.Duration `json:"delay,omitempty"`
+	// Jitter adds a random duration of up to it to Delay.
+	Jitter metav1.Duration `json:"jitter,omitempty"`
+	// StuckRatio is the fraction of resources, between 0 and 1, which stay
+	// pending forever.
+	StuckRatio float64 `json:"stuckRatio,omitempty"`
+}
+
+// Network configures the addresses of instances.
+type Network struct {
+	// PublicCIDR is the IPv4 network main IPs are allocated from.
+	PublicCIDR string `json:"publicCIDR,omitempty"`
+	// PrivateCIDR is the IPv4 network internal IPs of instances attached to
+	// a VPC are allocated from.
+	PrivateCIDR string `json:"privateCIDR,omitempty"`
+}
+
+// VPC is a VPC which exists in the simulator.
+type VPC struct {
+	ID          string `json:"id"`
+	Region      string `json:"region,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// FirewallGroup is a firewall group which exists in the simulator.
+type FirewallGroup struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+}
+
+// SSHKey is an SSH key which exists in the simulator.
+type SSHKey struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	SSHKey string `json:"sshKey,omitempty"`
+}
+
+// Snapshot is a snapshot which exists in the simulator.
+type Snapshot struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// Status defaults to complete.
+	Status string `json:"status,omitempty"`
+}
+
+// Fault makes matching API requests fail or respond slowly.
+type Fault struct {
+	// Method matches the HTTP method of a request, any method if empty.
+	Method string `json:"method,omitempty"`
+	// Path matches requests whose path starts with it, e.g.
+	// "/v2/instances". Any path if empty.
+	Path string `json:"path,omitempty"`
+	// StatusCode is sent instead of the regular response. Zero only applies
+	// Delay.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Message is the error message sent along with StatusCode.
+	Message string `json:"message,omitempty"`
+	// Delay is waited before the request is handled.
+	Delay metav1.Duration `json:"delay,omitempty"`
+	// Times is the number of matching requests the fault applies to. Zero
+	// applies it to every matching request.
+	Times int `json:"times,omitempty"`
+	// Probability is the chance, between 0 and 1, that the fault applies to a
+	// matching request. The fault always applies when zero.
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// LoadConfig reads a Config from the YAML file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read simulator config")
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse simulator config %s", path)
+	}
+	if err := config.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid simulator config %s", path)
+	}
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	for _, cidr := range []string{c.Network.PublicCIDR, c.Network.PrivateCIDR} {
+		if _, err := parseNetwork(cidr); err != nil {
+			return err
+		}
+	}
+	for _, ratio := range []float64{c.Instances.StuckRatio, c.LoadBalancers.StuckRatio} {
+		if ratio < 0 || ratio > 1 {
+			return errors.Errorf("stuckRatio %v is not between 0 and 1", ratio)
+		}
+	}
+	for _, f := range c.Faults {
+		if f.Probability < 0 || f.Probability > 1 {
+			return errors.Errorf("fault probability %v is not between 0 and 1", f.Probability)
+		}
+	}
+	return nil
+}
+
+// parseNetwork parses an optional IPv4 network.
+func parseNetwork(cidr string) (netip.Prefix, error) {
+	if cidr == "" {
+		return netip.Prefix{}, nil
+	}
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, errors.Wrapf(err, "invalid network %q", cidr)
+	}
+	if !p.Addr().Is4() || p.Bits() > 30 {
+		return netip.Prefix{}, errors.Errorf("network %q is not an IPv4 network with at least two hosts", cidr)
+	}
+	return p, nil
+}
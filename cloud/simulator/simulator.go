@@ -0,0 +1,114 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator runs the provider against a simulated Vultr API.
+//
+// The simulator is an in-process fake Vultr API server configured from a
+// file. The reconcilers talk to it through the regular clients, so rate
+// limiting, metrics and tracing behave as they do against the Vultr API.
+// This allows benchmarking the reconcilers at scale without creating
+// instances.
+package simulator
+
+import (
+	"github.com/vultr/govultr/v3"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+)
+
+// apiKey is the API key of the simulated Vultr API. It keeps the rate
+// limiter of the clients apart from those of real API keys.
+const apiKey = "simulator"
+
+// Simulator is a running simulated Vultr API.
+type Simulator struct {
+	server *fake.Server
+}
+
+// Start starts a simulator configured by config. It must be closed once it is
+// no longer used.
+func Start(config *Config) (*Simulator, error) {
+	publicNetwork, err := parseNetwork(config.Network.PublicCIDR)
+	if err != nil {
+		return nil, err
+	}
+	privateNetwork, err := parseNetwork(config.Network.PrivateCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	server := fake.NewServer(fake.Options{
+		APIKey:                      apiKey,
+		InstanceProvisionDelay:      config.Instances.Delay.Duration,
+		LoadBalancerProvisionDelay:  config.LoadBalancers.Delay.Duration,
+		InstanceProvisionJitter:     config.Instances.Jitter.Duration,
+		LoadBalancerProvisionJitter: config.LoadBalancers.Jitter.Duration,
+		InstanceStuckRatio:          config.Instances.StuckRatio,
+		LoadBalancerStuckRatio:      config.LoadBalancers.StuckRatio,
+		Latency:                     config.Latency.Duration,
+		RateLimit:                   config.RateLimit.QPS,
+		RateLimitBurst:              config.RateLimit.Burst,
+		PublicNetwork:               publicNetwork,
+		PrivateNetwork:              privateNetwork,
+	})
+	for _, vpc := range config.VPCs {
+		server.AddVPC(govultr.VPC{ID: vpc.ID, Region: vpc.Region, Description: vpc.Description})
+	}
+	for _, group := range config.FirewallGroups {
+		server.AddFirewallGroup(govultr.FirewallGroup{ID: group.ID, Description: group.Description})
+	}
+	for _, key := range config.SSHKeys {
+		server.AddSSHKey(govultr.SSHKey{ID: key.ID, Name: key.Name, SSHKey: key.SSHKey})
+	}
+	for _, snapshot := range config.Snapshots {
+		server.AddSnapshot(govultr.Snapshot{ID: snapshot.ID, Description: snapshot.Description, Status: snapshot.Status})
+	}
+	for _, f := range config.Faults {
+		server.InjectFault(fake.Fault{
+			Method:      f.Method,
+			Path:        f.Path,
+			StatusCode:  f.StatusCode,
+			Message:     f.Message,
+			Delay:       f.Delay.Duration,
+			Times:       f.Times,
+			Probability: f.Probability,
+		})
+	}
+
+	return &Simulator{server: server}, nil
+}
+
+// URL returns the base URL of the simulated Vultr API.
+func (s *Simulator) URL() string {
+	return s.server.URL
+}
+
+// ClientFactory returns a factory for clients talking to the simulator.
+func (s *Simulator) ClientFactory() scope.ClientFactory {
+	return scope.ClientFactoryFunc(func() (scope.VultrAPIClients, error) {
+		vultrClient, err := scope.NewVultrClient(apiKey, s.server.URL)
+		if err != nil {
+			return scope.VultrAPIClients{}, err
+		}
+		return scope.NewVultrAPIClients(vultrClient), nil
+	})
+}
+
+// Close stops the simulator.
+func (s *Simulator) Close() {
+	s.server.Close()
+}
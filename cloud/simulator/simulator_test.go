@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+)
+
+const testConfig = `
+instances:
+  delay: 1h
+network:
+  publicCIDR: 100.64.0.0/16
+  privateCIDR: 10.1.0.0/16
+vpcs:
+  - id: vpc
+    region: ewr
+sshKeys:
+  - id: key
+    name: admin
+faults:
+  - method: DELETE
+    path: /v2/instances
+    statusCode: 400
+    message: Unable to destroy server.
+`
+
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "simulator.yaml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSimulator(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	config, err := LoadConfig(writeConfig(t, testConfig))
+	g.Expect(err).NotTo(HaveOccurred())
+	sim, err := Start(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer sim.Close()
+
+	clients, err := sim.ClientFactory().NewClients()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	key, _, err := clients.SSHKeys.Get(ctx, "key")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(key.Name).To(Equal("admin"))
+
+	instance, _, err := clients.Instances.Create(ctx, &govultr.InstanceCreateReq{
+		Region:    "ewr",
+		Plan:      "vc2-2c-4gb",
+		AttachVPC: []string{"vpc"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.Status).To(Equal("pending"))
+	g.Expect(instance.MainIP).To(HavePrefix("100.64."))
+	g.Expect(instance.InternalIP).To(HavePrefix("10.1."))
+
+	err = clients.Instances.Delete(ctx, instance.ID)
+	g.Expect(err).To(MatchError(ContainSubstring("Unable to destroy server.")))
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	for name, config := range map[string]string{
+		"unknown field":    "instance:\n  delay: 1m\n",
+		"invalid network":  "network:\n  publicCIDR: 2001:db8::/64\n",
+		"invalid ratio":    "loadBalancers:\n  stuckRatio: 2\n",
+		"invalid duration": "latency: soon\n",
+		"invalid fault":    "faults:\n  - probability: -1\n",
+		"small network":    "network:\n  privateCIDR: 10.0.0.1/32\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := LoadConfig(writeConfig(t, config))
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
+)
+
+type instrumentedTransport struct {
+	next http.RoundTripper
+}
+
+// NewInstrumentedTransport returns a RoundTripper which records the count and
+// latency of every Vultr API request sent through next.
+func NewInstrumentedTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &instrumentedTransport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	service, operation := Operation(req)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	code := "error"
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode == http.StatusUnauthorized {
+			metrics.APIUnauthorizedRequestsTotal.Inc()
+		}
+	}
+
+	metrics.APIRequestsTotal.WithLabelValues(service, operation, code).Inc()
+	metrics.APIRequestDurationSeconds.WithLabelValues(service, operation, code).Observe(duration.Seconds())
+
+	return resp, err
+}
+
+// Operation derives the Vultr API service and operation of req from its
+// method and path, e.g. "instances" and "POST /instances/{id}/reboot" for
+// POST /v2/instances/8a7c.../reboot. Resource IDs are replaced so the
+// operation has a bounded cardinality.
+func Operation(req *http.Request) (service, operation string) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "v2" {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return "unknown", req.Method + " /"
+	}
+
+	// The API alternates collections and IDs: /{service}/{id}/{sub}/{id}.
+	for i := 1; i < len(segments); i += 2 {
+		segments[i] = "{id}"
+	}
+
+	return segments[0], req.Method + " /" + strings.Join(segments, "/")
+}
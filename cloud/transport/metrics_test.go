@@ -0,0 +1,51 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		method    string
+		url       string
+		service   string
+		operation string
+	}{
+		{http.MethodPost, "https://api.vultr.com/v2/instances", "instances", "POST /instances"},
+		{http.MethodGet, "https://api.vultr.com/v2/instances/cb676a46-66fd-4dfb-b839-443f2e6c0b60", "instances", "GET /instances/{id}"},
+		{http.MethodPost, "https://api.vultr.com/v2/instances/cb676a46/reboot", "instances", "POST /instances/{id}/reboot"},
+		{http.MethodGet, "https://api.vultr.com/v2/regions/ewr/availability?type=vc2", "regions", "GET /regions/{id}/availability"},
+		{http.MethodGet, "https://api.vultr.com/", "unknown", "GET /"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			service, operation := Operation(req)
+			g.Expect(service).To(Equal(tt.service))
+			g.Expect(operation).To(Equal(tt.operation))
+		})
+	}
+}
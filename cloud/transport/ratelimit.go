@@ -0,0 +1,188 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transport implements http.RoundTrippers wrapped around the govultr client.
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
+)
+
+const (
+	// DefaultQPS is the default sustained rate of Vultr API requests per account.
+	DefaultQPS = 20
+	// DefaultBurst is the default number of Vultr API requests allowed in a burst.
+	DefaultBurst = 10
+	// defaultRetryAfter is how long all requests are held back after a 429
+	// response without a usable Retry-After header.
+	defaultRetryAfter = time.Second
+)
+
+// RateLimitOptions configures the account-wide Vultr API rate limiter.
+type RateLimitOptions struct {
+	// QPS is the sustained number of requests per second.
+	QPS float64
+	// Burst is the maximum number of requests sent at once.
+	Burst int
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[string]*limiter{}
+	options    = RateLimitOptions{QPS: DefaultQPS, Burst: DefaultBurst}
+)
+
+// SetRateLimitOptions sets the options used for rate limiters created from
+// now on. It is meant to be called once, before any client is created.
+func SetRateLimitOptions(o RateLimitOptions) {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	options = o
+}
+
+// limiter is a token bucket shared by all clients of one Vultr account, which
+// additionally stops all requests after the API answered with 429.
+type limiter struct {
+	tokens *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// limiterKey returns the key of the limiter of apiKey, which keeps the key
+// itself out of memory dumps.
+func limiterKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// limiterFor returns the limiter shared by every client using apiKey.
+func limiterFor(apiKey string) *limiter {
+	key := limiterKey(apiKey)
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	l, ok := limiters[key]
+	if !ok {
+		l = &limiter{tokens: rate.NewLimiter(rate.Limit(options.QPS), options.Burst)}
+		limiters[key] = l
+	}
+	return l
+}
+
+// ReleaseRateLimiter drops the limiter of apiKey once the key is no longer
+// used, e.g. after it was rotated. Clients created before keep sharing it.
+func ReleaseRateLimiter(apiKey string) {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	delete(limiters, limiterKey(apiKey))
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return l.tokens.Wait(ctx)
+}
+
+// pause holds back all requests for d.
+func (l *limiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+type rateLimitedTransport struct {
+	limiter *limiter
+	next    http.RoundTripper
+}
+
+// NewRateLimitedTransport returns a RoundTripper which sends requests through
+// next, limited by the rate limiter shared by every client using apiKey.
+func NewRateLimitedTransport(apiKey string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitedTransport{
+		limiter: limiterFor(apiKey),
+		next:    next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	metrics.APIRateLimiterWaitingRequests.Inc()
+	err := t.limiter.wait(req.Context())
+	metrics.APIRateLimiterWaitingRequests.Dec()
+	metrics.APIRateLimiterWaitSeconds.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		metrics.APIThrottledRequestsTotal.Inc()
+		t.limiter.pause(retryAfter(resp))
+	}
+
+	return resp, err
+}
+
+// retryAfter parses the Retry-After header of resp, which is either a number
+// of seconds or an HTTP date.
+func retryAfter(resp *http.Response) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return defaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLimiterIsSharedPerAPIKey(t *testing.T) {
+	g := NewWithT(t)
+
+	a := NewRateLimitedTransport("key-a", nil).(*rateLimitedTransport)
+	b := NewRateLimitedTransport("key-a", nil).(*rateLimitedTransport)
+	c := NewRateLimitedTransport("key-c", nil).(*rateLimitedTransport)
+
+	g.Expect(a.limiter).To(BeIdenticalTo(b.limiter))
+	g.Expect(a.limiter).NotTo(BeIdenticalTo(c.limiter))
+}
+
+func TestReleaseRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	a := NewRateLimitedTransport("rotated-key", nil).(*rateLimitedTransport)
+	ReleaseRateLimiter("rotated-key")
+
+	limitersMu.Lock()
+	g.Expect(limiters).NotTo(HaveKey(limiterKey("rotated-key")))
+	limitersMu.Unlock()
+
+	b := NewRateLimitedTransport("rotated-key", nil).(*rateLimitedTransport)
+	g.Expect(b.limiter).NotTo(BeIdenticalTo(a.limiter))
+}
+
+func TestRateLimitedTransportHonoursRetryAfter(t *testing.T) {
+	g := NewWithT(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitedTransport("retry-after", nil)}
+
+	resp, err := client.Get(server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Body.Close()).To(Succeed())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+
+	start := time.Now()
+	resp, err = client.Get(server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Body.Close()).To(Succeed())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 900*time.Millisecond))
+}
+
+func TestRetryAfter(t *testing.T) {
+	g := NewWithT(t)
+
+	resp := &http.Response{Header: http.Header{}}
+	g.Expect(retryAfter(resp)).To(Equal(defaultRetryAfter))
+
+	resp.Header.Set("Retry-After", "3")
+	g.Expect(retryAfter(resp)).To(Equal(3 * time.Second))
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	g.Expect(retryAfter(resp)).To(BeZero())
+}
@@ -17,26 +17,40 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	capvmetrics "github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/simulator"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/transport"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
 	"github.com/vultr/cluster-api-provider-vultr/util/reconciler"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	infrav1beta2 "github.com/vultr/cluster-api-provider-vultr/api/v1beta2"
 	controllers "github.com/vultr/cluster-api-provider-vultr/internal/controller"
 	//+kubebuilder:scaffold:imports
 )
@@ -48,12 +62,27 @@ var (
 
 var (
 	reconcileTimeout time.Duration
+	resyncPeriod     time.Duration
+	apiQPS           float64
+	apiBurst         int
+	tracingOptions   tracing.Options
+	simulatorConfig  string
+	watchFilterValue string
+	watchNamespaces  string
+
+	vultrClusterConcurrency         int
+	vultrMachineConcurrency         int
+	vultrMachineTemplateConcurrency int
+	intervals                       reconciler.Intervals
+	backoffBaseDelay                time.Duration
+	backoffMaxDelay                 time.Duration
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(capi.AddToScheme(scheme))
 	utilruntime.Must(infrav1.AddToScheme(scheme))
+	utilruntime.Must(infrav1beta2.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -64,6 +93,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var webhookPort int
+	var webhookCertDir string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":9440", "The address the probe endpoint binds to.")
@@ -75,7 +106,47 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&webhookPort, "webhook-port", 9443,
+		"The port the conversion webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory containing the serving certificate of the webhook server.")
 	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", reconciler.DefaultLoopTimeout, "The maximum duration a reconcile loop can run (e.g. 90m)")
+	flag.DurationVar(&resyncPeriod, "machine-resync-period", reconciler.DefaultResyncPeriod,
+		"How often ready VultrMachines are reconciled to detect instances changed or deleted outside of Cluster API (e.g. 5m)")
+	flag.Float64Var(&apiQPS, "vultr-api-qps", transport.DefaultQPS,
+		"Maximum sustained number of Vultr API requests per second, shared by all clients using the same API key.")
+	flag.IntVar(&apiBurst, "vultr-api-burst", transport.DefaultBurst,
+		"Maximum number of Vultr API requests sent in a single burst.")
+	flag.BoolVar(&tracingOptions.Enabled, "enable-tracing", false,
+		"If set, OpenTelemetry traces of reconciles and Vultr API calls are exported over OTLP/HTTP.")
+	flag.StringVar(&tracingOptions.Endpoint, "tracing-endpoint", "",
+		"The host:port of the OTLP/HTTP trace collector. Defaults to OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318.")
+	flag.BoolVar(&tracingOptions.Insecure, "tracing-insecure", false,
+		"If set, traces are sent to the collector without TLS.")
+	flag.Float64Var(&tracingOptions.SamplingRatio, "tracing-sampling-ratio", 1.0,
+		"The fraction of reconciles which are traced, between 0 and 1.")
+	flag.StringVar(&watchFilterValue, "watch-filter", "",
+		fmt.Sprintf("Label value that the controllers watch to reconcile cluster-api objects. Label key is always %s. If unspecified, the controllers watch all cluster-api objects.", capi.WatchLabel))
+	flag.StringVar(&watchNamespaces, "namespace", "",
+		"Comma-separated list of namespaces that the controllers watch to reconcile cluster-api objects. If unspecified, the controllers watch all namespaces.")
+	flag.IntVar(&vultrClusterConcurrency, "vultrcluster-concurrency", 1,
+		"Number of VultrClusters to process simultaneously.")
+	flag.IntVar(&vultrMachineConcurrency, "vultrmachine-concurrency", 1,
+		"Number of VultrMachines to process simultaneously.")
+	flag.IntVar(&vultrMachineTemplateConcurrency, "vultrmachinetemplate-concurrency", 1,
+		"Number of VultrMachineTemplates to process simultaneously.")
+	flag.DurationVar(&intervals.Poll, "poll-interval", reconciler.DefaultPollInterval,
+		"How often instances and load balancers which are being provisioned or changed are checked (e.g. 10s).")
+	flag.DurationVar(&intervals.Retry, "retry-interval", reconciler.DefaultRetryInterval,
+		"How often conditions which need a change outside of the provider, e.g. a snapshot which is not ready or a stopped instance, are checked (e.g. 1m).")
+	flag.Float64Var(&intervals.Jitter, "poll-jitter", 0.1,
+		"Fraction, between 0 and 1, by which the poll and retry intervals are randomly extended.")
+	flag.DurationVar(&backoffBaseDelay, "backoff-base-delay", reconciler.DefaultBackoffBaseDelay,
+		"Delay before a failed reconcile is retried for the first time, doubled on every further failure.")
+	flag.DurationVar(&backoffMaxDelay, "backoff-max-delay", reconciler.DefaultBackoffMaxDelay,
+		"Maximum delay between retries of a failed reconcile (e.g. 5m).")
+	flag.StringVar(&simulatorConfig, "simulator-config", "",
+		"If set, the provider talks to a simulated Vultr API configured by this file instead of the Vultr API. For scale testing only.")
 
 	opts := zap.Options{
 		Development: true,
@@ -83,18 +154,57 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctrl.SetLogger(logging.NewRedactingLogger(zap.New(zap.UseFlagOptions(&opts))))
+
+	transport.SetRateLimitOptions(transport.RateLimitOptions{
+		QPS:   apiQPS,
+		Burst: apiBurst,
+	})
 
 	ctx := ctrl.SetupSignalHandler()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	// HTTP/2 is disabled by default to avoid the HTTP/2 Stream Cancellation and
+	// Rapid Reset CVEs.
+	var tlsOpts []func(*tls.Config)
+	if !enableHTTP2 {
+		tlsOpts = append(tlsOpts, func(c *tls.Config) {
+			c.NextProtos = []string{"http/1.1"}
+		})
+	}
+
+	webhookServer := webhook.NewServer(webhook.Options{
+		Port:    webhookPort,
+		CertDir: webhookCertDir,
+		TLSOpts: tlsOpts,
+	})
+
+	var cacheOptions cache.Options
+	if strings.Trim(watchNamespaces, ", ") != "" {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(watchNamespaces, ",") {
+			// An empty namespace would watch all namespaces.
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+			}
+		}
+		setupLog.Info("watching objects only in namespaces", "namespaces", watchNamespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
-			//TLSOpts:       tlsOpts,
+			TLSOpts:       tlsOpts,
 		},
-		//WebhookServer:          webhookServer,
+		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "836d4a75.cluster.x-k8s.io",
@@ -115,11 +225,43 @@ func main() {
 		os.Exit(1)
 	}
 
+	var clientFactory scope.ClientFactory = scope.EnvClientFactory{}
+	if path := os.Getenv(scope.APIKeyFileEnv); path != "" {
+		fileClientFactory, err := scope.NewFileClientFactory(path, os.Getenv("VULTR_API_URL"), ctrl.Log.WithName("credentials"))
+		if err != nil {
+			setupLog.Error(err, "unable to load the Vultr API key")
+			os.Exit(1)
+		}
+		if err := mgr.Add(fileClientFactory); err != nil {
+			setupLog.Error(err, "unable to watch the Vultr API key")
+			os.Exit(1)
+		}
+		clientFactory = fileClientFactory
+	}
+	if simulatorConfig != "" {
+		config, err := simulator.LoadConfig(simulatorConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to load simulator config")
+			os.Exit(1)
+		}
+		sim, err := simulator.Start(config)
+		if err != nil {
+			setupLog.Error(err, "unable to start simulator")
+			os.Exit(1)
+		}
+		defer sim.Close()
+		setupLog.Info("using a simulated Vultr API, no Vultr resources are created", "url", sim.URL())
+		clientFactory = sim.ClientFactory()
+	}
+
 	if err = (&controllers.VultrClusterReconciler{
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		Recorder:         mgr.GetEventRecorderFor("vultrcluster-controller"),
-	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
+		WatchFilterValue: watchFilterValue,
+		Intervals:        intervals,
+		ClientFactory:    clientFactory,
+	}).SetupWithManager(ctx, mgr, controllerOptions(vultrClusterConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VultrCluster")
 		os.Exit(1)
 	}
@@ -127,12 +269,47 @@ func main() {
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		Recorder:         mgr.GetEventRecorderFor("vultrmachine-controller"),
-	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
+		ResyncPeriod:     resyncPeriod,
+		WatchFilterValue: watchFilterValue,
+		Intervals:        intervals,
+		ClientFactory:    clientFactory,
+	}).SetupWithManager(ctx, mgr, controllerOptions(vultrMachineConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VultrMachine")
 		os.Exit(1)
 	}
+	if err = (&controllers.VultrMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		ReconcileTimeout: reconcileTimeout,
+		Recorder:         mgr.GetEventRecorderFor("vultrmachinetemplate-controller"),
+		WatchFilterValue: watchFilterValue,
+		ClientFactory:    clientFactory,
+	}).SetupWithManager(ctx, mgr, controllerOptions(vultrMachineTemplateConcurrency)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VultrMachineTemplate")
+		os.Exit(1)
+	}
+	if err = (&infrav1.VultrCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VultrCluster")
+		os.Exit(1)
+	}
+	if err = (&infrav1.VultrMachine{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VultrMachine")
+		os.Exit(1)
+	}
+	if err = (&infrav1.VultrClusterTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VultrClusterTemplate")
+		os.Exit(1)
+	}
+	if err = (&infrav1.VultrMachineTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VultrMachineTemplate")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err := ctrlmetrics.Registry.Register(capvmetrics.NewInstanceCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register instance metrics collector")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -147,4 +324,19 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "problem flushing traces")
+	}
+}
+
+// controllerOptions returns the options of a controller processing
+// concurrency objects at once.
+func controllerOptions(concurrency int) controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: concurrency,
+		RateLimiter:             reconciler.NewRateLimiter(backoffBaseDelay, backoffMaxDelay),
+	}
 }
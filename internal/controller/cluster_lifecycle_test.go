@@ -0,0 +1,802 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vultr/govultr/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+)
+
+const (
+	lifecycleTimeout  = 30 * time.Second
+	lifecycleInterval = 250 * time.Millisecond
+)
+
+// testCluster creates the objects which Cluster API and the user would
+// create for a workload cluster, and plays the part of the Cluster API
+// controllers in the tests.
+type testCluster struct {
+	namespace    string
+	cluster      *clusterv1.Cluster
+	vultrCluster *infrav1.VultrCluster
+}
+
+func newTestCluster(ctx context.Context) *testCluster {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "lifecycle-"}}
+	Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+	tc := &testCluster{namespace: ns.Name}
+	tc.cluster = &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: ns.Name},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "VultrCluster",
+				Name:       "workload",
+				Namespace:  ns.Name,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, tc.cluster)).To(Succeed())
+
+	tc.vultrCluster = &infrav1.VultrCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload",
+			Namespace: ns.Name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       tc.cluster.Name,
+				UID:        tc.cluster.UID,
+			}},
+		},
+		Spec: infrav1.VultrClusterSpec{Region: "ewr"},
+	}
+	Expect(k8sClient.Create(ctx, tc.vultrCluster)).To(Succeed())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: ns.Name},
+		Data:       map[string][]byte{"value": []byte("#cloud-config\nruncmd:\n  - kubeadm init\n")},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+	return tc
+}
+
+// markInfrastructureReady mirrors the VultrCluster status to the Cluster,
+// as the Cluster API cluster controller does.
+func (tc *testCluster) markInfrastructureReady(ctx context.Context) {
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.cluster), tc.cluster)).To(Succeed())
+		tc.cluster.Status.InfrastructureReady = true
+		g.Expect(k8sClient.Status().Update(ctx, tc.cluster)).To(Succeed())
+	}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+}
+
+// createMachine creates a Machine and the VultrMachine it owns, opts modify
+// the VultrMachine before it is created.
+func (tc *testCluster) createMachine(ctx context.Context, name string, controlPlane bool, opts ...func(*infrav1.VultrMachine)) *infrav1.VultrMachine {
+	labels := map[string]string{clusterv1.ClusterNameLabel: tc.cluster.Name}
+	if controlPlane {
+		labels[clusterv1.MachineControlPlaneLabel] = ""
+	}
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.namespace, Labels: labels},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: tc.cluster.Name,
+			Bootstrap:   clusterv1.Bootstrap{DataSecretName: ptr.To("bootstrap")},
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "VultrMachine",
+				Name:       name,
+				Namespace:  tc.namespace,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+	vultrMachine := &infrav1.VultrMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tc.namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Machine",
+				Name:       machine.Name,
+				UID:        machine.UID,
+			}},
+		},
+		Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
+	}
+	for _, opt := range opts {
+		opt(vultrMachine)
+	}
+	Expect(k8sClient.Create(ctx, vultrMachine)).To(Succeed())
+	return vultrMachine
+}
+
+// instances returns the instances of the cluster in the fake Vultr API.
+func (tc *testCluster) instances() []govultr.Instance {
+	var instances []govultr.Instance
+	for _, i := range vultrAPI.Instances() {
+		if slices.ContainsFunc(i.Tags, func(tag string) bool { return strings.Contains(tag, string(tc.cluster.UID)) }) {
+			instances = append(instances, i)
+		}
+	}
+	return instances
+}
+
+// loadBalancers returns the load balancers of the cluster in the fake Vultr
+// API.
+func (tc *testCluster) loadBalancers() []govultr.LoadBalancer {
+	var lbs []govultr.LoadBalancer
+	for _, lb := range vultrAPI.LoadBalancers() {
+		if strings.HasSuffix(lb.Label, string(tc.cluster.UID)) {
+			lbs = append(lbs, lb)
+		}
+	}
+	return lbs
+}
+
+// providerTags returns the tags the provider sets on the instance of a
+// machine of the cluster.
+func (tc *testCluster) providerTags(machineName, role string) []string {
+	return infrav1.BuildTags(infrav1.BuildTagParams{
+		ClusterName: tc.cluster.Name,
+		ClusterUID:  string(tc.cluster.UID),
+		Name:        machineName,
+		Role:        role,
+	})
+}
+
+// waitForMachineReady waits until the VultrMachine is ready and returns the
+// ID of its instance.
+func waitForMachineReady(ctx context.Context, vultrMachine *infrav1.VultrMachine) string {
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrMachine), vultrMachine)).To(Succeed())
+		g.Expect(vultrMachine.Status.Ready).To(BeTrue())
+		g.Expect(vultrMachine.Spec.ProviderID).NotTo(BeNil())
+	}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+	return (*vultrMachine.Spec.ProviderID)[len("vultr://"):]
+}
+
+// waitForDeletion waits until obj is gone.
+func waitForDeletion(ctx context.Context, obj client.Object) {
+	Eventually(func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+	}, lifecycleTimeout, lifecycleInterval).Should(BeTrue())
+}
+
+var _ = Describe("Cluster lifecycle", func() {
+	ctx := context.Background()
+
+	AfterEach(func() {
+		vultrAPI.ClearFaults()
+		vultrAPI.SetInstanceProvisionDelay(0)
+		vultrAPI.SetLoadBalancerProvisionDelay(0)
+	})
+
+	It("creates, scales and deletes a cluster", func() {
+		tc := newTestCluster(ctx)
+
+		By("provisioning the API server load balancer")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+			g.Expect(tc.vultrCluster.Spec.ControlPlaneEndpoint.Host).NotTo(BeEmpty())
+			g.Expect(conditions.IsTrue(tc.vultrCluster, infrav1.LoadBalancerReadyCondition)).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		lbID := tc.vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+		lb, ok := vultrAPI.LoadBalancer(lbID)
+		Expect(ok).To(BeTrue())
+		Expect(tc.vultrCluster.Spec.ControlPlaneEndpoint.Host).To(Equal(lb.IPV4))
+		tc.markInfrastructureReady(ctx)
+
+		By("creating a control plane machine")
+		controlPlane := tc.createMachine(ctx, "workload-control-plane-0", true)
+		controlPlaneID := waitForMachineReady(ctx, controlPlane)
+		Expect(controlPlane.Status.Addresses).NotTo(BeEmpty())
+		Eventually(func() []string {
+			lb, _ := vultrAPI.LoadBalancer(lbID)
+			return lb.Instances
+		}, lifecycleTimeout, lifecycleInterval).Should(ConsistOf(controlPlaneID))
+
+		By("scaling up the workers")
+		workers := []*infrav1.VultrMachine{
+			tc.createMachine(ctx, "workload-md-0", false),
+			tc.createMachine(ctx, "workload-md-1", false),
+		}
+		for _, w := range workers {
+			waitForMachineReady(ctx, w)
+		}
+		Expect(tc.instances()).To(HaveLen(3))
+
+		By("scaling down the workers")
+		workerID := waitForMachineReady(ctx, workers[1])
+		Expect(k8sClient.Delete(ctx, workers[1])).To(Succeed())
+		waitForDeletion(ctx, workers[1])
+		_, ok = vultrAPI.Instance(workerID)
+		Expect(ok).To(BeFalse())
+		Expect(tc.instances()).To(HaveLen(2))
+
+		By("deleting the cluster")
+		Expect(k8sClient.Delete(ctx, workers[0])).To(Succeed())
+		Expect(k8sClient.Delete(ctx, controlPlane)).To(Succeed())
+		waitForDeletion(ctx, workers[0])
+		waitForDeletion(ctx, controlPlane)
+		Expect(k8sClient.Delete(ctx, tc.vultrCluster)).To(Succeed())
+		waitForDeletion(ctx, tc.vultrCluster)
+		Expect(tc.instances()).To(BeEmpty())
+		Expect(tc.loadBalancers()).To(BeEmpty())
+	})
+
+	It("waits for slow instances and load balancers", func() {
+		vultrAPI.SetLoadBalancerProvisionDelay(vultrfake.Never)
+		vultrAPI.SetInstanceProvisionDelay(vultrfake.Never)
+		tc := newTestCluster(ctx)
+
+		By("getting the API server endpoint while the load balancer is pending")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		lbID := tc.vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+		tc.markInfrastructureReady(ctx)
+
+		By("keeping the machine pending while the instance provisions")
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(conditions.GetReason(worker, infrav1.InstanceRunningCondition)).To(Equal(infrav1.InstancePendingReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(worker.Status.Ready).To(BeFalse())
+		Expect(worker.Spec.ProviderID).NotTo(BeNil())
+
+		instanceID := (*worker.Spec.ProviderID)[len("vultr://"):]
+		Expect(vultrAPI.SetInstanceStatus(instanceID, "active", "running")).To(BeTrue())
+		waitForMachineReady(ctx, worker)
+
+		By("attaching control plane instances once the load balancer is active")
+		controlPlane := tc.createMachine(ctx, "workload-control-plane-0", true)
+		// The reconciler waits for the load balancer before it records the
+		// provider ID, so look the instance up by its label.
+		var controlPlaneID string
+		Eventually(func() string {
+			for _, i := range tc.instances() {
+				if i.Label == controlPlane.Name {
+					controlPlaneID = i.ID
+				}
+			}
+			return controlPlaneID
+		}, lifecycleTimeout, lifecycleInterval).ShouldNot(BeEmpty())
+		Consistently(func() []string {
+			lb, _ := vultrAPI.LoadBalancer(lbID)
+			return lb.Instances
+		}, time.Second, lifecycleInterval).Should(BeEmpty())
+
+		Expect(vultrAPI.SetInstanceStatus(controlPlaneID, "active", "running")).To(BeTrue())
+		Expect(vultrAPI.SetLoadBalancerStatus(lbID, "active")).To(BeTrue())
+		Expect(waitForMachineReady(ctx, controlPlane)).To(Equal(controlPlaneID))
+		lb, _ := vultrAPI.LoadBalancer(lbID)
+		Expect(lb.Instances).To(ConsistOf(controlPlaneID))
+	})
+
+	It("retries transient Vultr API errors", func() {
+		tc := newTestCluster(ctx)
+		vultrAPI.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/load-balancers", StatusCode: http.StatusInternalServerError, Times: 5})
+		vultrAPI.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/instances", StatusCode: http.StatusTooManyRequests, Times: 5})
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(tc.vultrCluster.Status.FailureReason).To(BeNil())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		waitForMachineReady(ctx, worker)
+		Expect(worker.Status.FailureReason).To(BeNil())
+	})
+
+	It("fails machines on terminal Vultr API errors", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		vultrAPI.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/instances", StatusCode: http.StatusBadRequest, Message: "Invalid plan chosen."})
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(worker.Status.FailureReason).NotTo(BeNil())
+			g.Expect(worker.Status.FailureMessage).To(HaveValue(ContainSubstring("Invalid plan chosen.")))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(conditions.GetReason(worker, infrav1.InstanceProvisionedCondition)).To(Equal(infrav1.InstanceProvisionFailedReason))
+		Expect(tc.instances()).To(BeEmpty())
+	})
+
+	It("reports missing VPCs", func() {
+		vpcID := vultrAPI.AddVPC(govultr.VPC{Region: "ewr"})
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false, func(m *infrav1.VultrMachine) {
+			m.Spec.VPCID = "missing"
+		})
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(conditions.GetReason(worker, infrav1.VPCReadyCondition)).To(Equal(infrav1.VPCNotFoundReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			worker.Spec.VPCID = vpcID
+			g.Expect(k8sClient.Update(ctx, worker)).To(Succeed())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		instanceID := waitForMachineReady(ctx, worker)
+		instance, _ := vultrAPI.Instance(instanceID)
+		Expect(instance.InternalIP).NotTo(BeEmpty())
+	})
+
+	It("tracks the power state of instances", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		instanceID := waitForMachineReady(ctx, worker)
+		Expect(worker.Status.PowerStatus).To(HaveValue(Equal(infrav1.PowerStatusRunning)))
+		Expect(worker.Status.ServerState).To(HaveValue(Equal(infrav1.ServerStateOK)))
+
+		// A stopped instance is left alone by default.
+		Expect(vultrAPI.SetInstanceStatus(instanceID, "active", "stopped")).To(BeTrue())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(worker.Status.Ready).To(BeFalse())
+			g.Expect(worker.Status.PowerStatus).To(HaveValue(Equal(infrav1.PowerStatusStopped)))
+			g.Expect(conditions.GetReason(worker, infrav1.InstanceRunningCondition)).To(Equal(infrav1.InstanceStoppedReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(vultrAPI.Requests(http.MethodPost, "/v2/instances/"+instanceID+"/start")).To(BeZero())
+
+		// It is started again with the Always start policy.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			worker.Spec.StartPolicy = infrav1.StartPolicyAlways
+			g.Expect(k8sClient.Update(ctx, worker)).To(Succeed())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		waitForMachineReady(ctx, worker)
+		Expect(vultrAPI.Requests(http.MethodPost, "/v2/instances/"+instanceID+"/start")).To(Equal(1))
+	})
+
+	It("fails machines whose instance was deleted", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		instanceID := waitForMachineReady(ctx, worker)
+
+		// The resync notices the instance is gone and does not recreate it.
+		Expect(vultrAPI.RemoveInstance(instanceID)).To(BeTrue())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(worker.Status.Ready).To(BeFalse())
+			g.Expect(worker.Status.FailureReason).NotTo(BeNil())
+			g.Expect(conditions.GetReason(worker, infrav1.InstanceProvisionedCondition)).To(Equal(infrav1.InstanceDeletedReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(tc.instances()).To(BeEmpty())
+	})
+
+	It("does not recreate load balancers deleted outside of Cluster API", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		lbID := tc.vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+
+		// Changing the VultrCluster triggers a reconcile.
+		Expect(vultrAPI.RemoveLoadBalancer(lbID)).To(BeTrue())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			tc.vultrCluster.Annotations = map[string]string{"test": "lb-removed"}
+			g.Expect(k8sClient.Update(ctx, tc.vultrCluster)).To(Succeed())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(conditions.GetReason(tc.vultrCluster, infrav1.LoadBalancerReadyCondition)).To(Equal(infrav1.LoadBalancerNotFoundReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(tc.loadBalancers()).To(BeEmpty())
+
+		// The cluster can still be deleted.
+		Expect(k8sClient.Delete(ctx, tc.vultrCluster)).To(Succeed())
+		waitForDeletion(ctx, tc.vultrCluster)
+	})
+
+	It("performs actions requested through annotations", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		controlPlane := tc.createMachine(ctx, "workload-control-plane-0", true)
+		controlPlaneID := waitForMachineReady(ctx, controlPlane)
+		worker := tc.createMachine(ctx, "workload-md-0", false, func(m *infrav1.VultrMachine) {
+			m.Spec.StartPolicy = infrav1.StartPolicyAlways
+		})
+		workerID := waitForMachineReady(ctx, worker)
+
+		annotate := func(m *infrav1.VultrMachine, annotations map[string]string) {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(m), m)).To(Succeed())
+				m.Annotations = annotations
+				g.Expect(k8sClient.Update(ctx, m)).To(Succeed())
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+		waitForAction := func(m *infrav1.VultrMachine, action infrav1.MachineAction, result infrav1.MachineActionResult) {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(m), m)).To(Succeed())
+				g.Expect(m.Annotations).NotTo(HaveKey(infrav1.ActionAnnotation))
+				g.Expect(m.Status.LastAction).NotTo(BeNil())
+				g.Expect(m.Status.LastAction.Action).To(Equal(action))
+				g.Expect(m.Status.LastAction.Result).To(Equal(result))
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+
+		// Control plane machines need the force annotation.
+		annotate(controlPlane, map[string]string{infrav1.ActionAnnotation: "reboot"})
+		waitForAction(controlPlane, infrav1.MachineActionReboot, infrav1.MachineActionRejected)
+		Expect(vultrAPI.Requests(http.MethodPost, "/v2/instances/"+controlPlaneID+"/reboot")).To(BeZero())
+		annotate(controlPlane, map[string]string{infrav1.ActionAnnotation: "reboot", infrav1.ForceActionAnnotation: "true"})
+		waitForAction(controlPlane, infrav1.MachineActionReboot, infrav1.MachineActionSucceeded)
+		Expect(vultrAPI.Requests(http.MethodPost, "/v2/instances/"+controlPlaneID+"/reboot")).To(Equal(1))
+
+		// Halted instances stay stopped despite the start policy.
+		annotate(worker, map[string]string{infrav1.ActionAnnotation: "halt"})
+		waitForAction(worker, infrav1.MachineActionHalt, infrav1.MachineActionSucceeded)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(worker.Status.Ready).To(BeFalse())
+			g.Expect(conditions.GetReason(worker, infrav1.InstanceRunningCondition)).To(Equal(infrav1.InstanceStoppedReason))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(vultrAPI.Requests(http.MethodPost, "/v2/instances/"+workerID+"/start")).To(BeZero())
+
+		annotate(worker, map[string]string{infrav1.ActionAnnotation: "start"})
+		waitForAction(worker, infrav1.MachineActionStart, infrav1.MachineActionSucceeded)
+		waitForMachineReady(ctx, worker)
+
+		annotate(worker, map[string]string{infrav1.ActionAnnotation: "explode"})
+		waitForAction(worker, infrav1.MachineAction("explode"), infrav1.MachineActionRejected)
+	})
+
+	It("upgrades instance plans in place", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+		instanceID := waitForMachineReady(ctx, worker)
+		Expect(conditions.Has(worker, infrav1.PlanUpToDateCondition)).To(BeFalse())
+
+		setPlan := func(plan string, inPlace bool) {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+				worker.Spec.PlanID = plan
+				worker.Spec.InPlacePlanUpgrade = inPlace
+				g.Expect(k8sClient.Update(ctx, worker)).To(Succeed())
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+		waitForReason := func(reason string) {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+				g.Expect(conditions.GetReason(worker, infrav1.PlanUpToDateCondition)).To(Equal(reason))
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+
+		// Plan changes are ignored unless in-place upgrades are enabled.
+		setPlan("vc2-4c-8gb", false)
+		waitForReason(infrav1.PlanUpgradeDisabledReason)
+		Expect(worker.Status.Ready).To(BeTrue())
+
+		// The machine is not ready while the instance is upgraded.
+		vultrAPI.SetInstanceProvisionDelay(2 * time.Second)
+		setPlan("vc2-4c-8gb", true)
+		waitForReason(infrav1.PlanUpgradingReason)
+		Expect(worker.Status.Ready).To(BeFalse())
+		Expect(worker.Status.PlanUpgrade).NotTo(BeNil())
+		Expect(worker.Status.PlanUpgrade.FromPlan).To(Equal("vc2-2c-4gb"))
+
+		waitForMachineReady(ctx, worker)
+		Expect(conditions.IsTrue(worker, infrav1.PlanUpToDateCondition)).To(BeTrue())
+		Expect(worker.Status.PlanUpgrade).To(BeNil())
+		instance, ok := vultrAPI.Instance(instanceID)
+		Expect(ok).To(BeTrue())
+		Expect(instance.Plan).To(Equal("vc2-4c-8gb"))
+
+		// Downgrades are rejected.
+		setPlan("vc2-2c-4gb", true)
+		waitForReason(infrav1.PlanUpgradeRejectedReason)
+		Expect(worker.Status.Ready).To(BeTrue())
+		Expect(vultrAPI.Requests(http.MethodPatch, "/v2/instances/"+instanceID)).To(Equal(1))
+	})
+
+	It("attaches data volumes and cleans them up", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false, func(m *infrav1.VultrMachine) {
+			m.Spec.DataVolumes = []infrav1.DataVolume{
+				{Label: "data", SizeGB: 20, Filesystem: "ext4", MountPath: "/var/lib/data"},
+				{Label: "backup", SizeGB: 40, Type: infrav1.VolumeTypeHDD, DeletionPolicy: infrav1.VolumeDeletionPolicyRetain},
+			}
+		})
+		instanceID := waitForMachineReady(ctx, worker)
+		Expect(conditions.IsTrue(worker, infrav1.DataVolumesReadyCondition)).To(BeTrue())
+		Expect(worker.Status.DataVolumes).To(HaveLen(2))
+
+		ids := map[string]string{}
+		for _, volume := range worker.Status.DataVolumes {
+			Expect(volume.Attached).To(BeTrue())
+			block, ok := vultrAPI.Block(volume.ID)
+			Expect(ok).To(BeTrue())
+			Expect(block.AttachedToInstance).To(Equal(instanceID))
+			ids[volume.Label] = volume.ID
+		}
+		userData, _ := vultrAPI.InstanceUserData(instanceID)
+		Expect(userData).To(ContainSubstring("/var/lib/data"))
+
+		// Deleting the machine deletes the volume unless it is retained.
+		Expect(k8sClient.Delete(ctx, worker)).To(Succeed())
+		waitForDeletion(ctx, worker)
+		_, ok := vultrAPI.Block(ids["data"])
+		Expect(ok).To(BeFalse())
+		block, ok := vultrAPI.Block(ids["backup"])
+		Expect(ok).To(BeTrue())
+		Expect(block.AttachedToInstance).To(BeEmpty())
+	})
+
+	It("provisions bare metal workers", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-metal", false, func(m *infrav1.VultrMachine) {
+			m.Spec.MachineType = infrav1.MachineTypeBareMetal
+			m.Spec.PlanID = "vbm-4c-32gb"
+		})
+		serverID := waitForMachineReady(ctx, worker)
+		server, ok := vultrAPI.BareMetal(serverID)
+		Expect(ok).To(BeTrue())
+		Expect(server.Label).To(Equal(worker.Name))
+		Expect(worker.Status.Addresses).To(ContainElement(corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: server.MainIP}))
+		_, ok = vultrAPI.Instance(serverID)
+		Expect(ok).To(BeFalse())
+
+		// The kubelet sets the provider ID of the Node from the instance_id
+		// metadata, Cluster API matches Machines to Nodes by exact provider ID.
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: worker.Name},
+			Spec:       corev1.NodeSpec{ProviderID: "vultr://" + server.ID},
+		}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, node))).To(Succeed()) })
+		Expect(worker.Spec.ProviderID).To(HaveValue(Equal(node.Spec.ProviderID)))
+
+		Expect(k8sClient.Delete(ctx, worker)).To(Succeed())
+		waitForDeletion(ctx, worker)
+		_, ok = vultrAPI.BareMetal(serverID)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("rejects changes of the machine type", func() {
+		tc := newTestCluster(ctx)
+		worker := tc.createMachine(ctx, "workload-md-0", false)
+
+		setMachineType := func(machineType infrav1.MachineType) func(g Gomega) error {
+			return func(g Gomega) error {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+				worker.Spec.MachineType = machineType
+				err := k8sClient.Update(ctx, worker)
+				g.Expect(apierrors.IsConflict(err)).To(BeFalse())
+				return err
+			}
+		}
+
+		// The type defaults to Instance, so setting it later is a change too.
+		Eventually(setMachineType(infrav1.MachineTypeBareMetal), lifecycleTimeout, lifecycleInterval).
+			Should(MatchError(ContainSubstring("machineType is immutable")))
+		Eventually(setMachineType(infrav1.MachineTypeInstance), lifecycleTimeout, lifecycleInterval).
+			Should(Succeed())
+	})
+
+	It("applies additional tags and restores them on drift", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		worker := tc.createMachine(ctx, "workload-md-0", false, func(m *infrav1.VultrMachine) {
+			m.Spec.AdditionalTags = []string{"team:platform"}
+		})
+		instanceID := waitForMachineReady(ctx, worker)
+		instance, ok := vultrAPI.Instance(instanceID)
+		Expect(ok).To(BeTrue())
+		Expect(instance.Tags).To(ContainElements(infrav1.ClusterNameTag(tc.cluster.Name), "team:platform"))
+		waitForTags := func(tags ...string) {
+			Eventually(func(g Gomega) {
+				instance, ok := vultrAPI.Instance(instanceID)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(instance.Tags).To(ConsistOf(append(tc.providerTags(worker.Name, infrav1.NodeRoleTagValue), tags...)))
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+
+		// Tags of the cluster are added to existing instances.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			tc.vultrCluster.Spec.AdditionalTags = []string{"cost-center:42"}
+			g.Expect(k8sClient.Update(ctx, tc.vultrCluster)).To(Succeed())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		waitForTags("cost-center:42", "team:platform")
+
+		// Tags of the provider changed outside of Cluster API are restored,
+		// other tags are kept.
+		_, _, err := vultrAPI.VultrClient().Instance.Update(ctx, instanceID, &govultr.InstanceUpdateReq{Tags: []string{"billing:console"}})
+		Expect(err).NotTo(HaveOccurred())
+		waitForTags("cost-center:42", "team:platform", "billing:console")
+
+		// Additional tags removed from the spec are removed from the instance.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			worker.Spec.AdditionalTags = nil
+			g.Expect(k8sClient.Update(ctx, worker)).To(Succeed())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		waitForTags("cost-center:42", "billing:console")
+
+		// Reserved tags are rejected.
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+		worker.Spec.AdditionalTags = []string{"name:other"}
+		Expect(k8sClient.Update(ctx, worker)).NotTo(Succeed())
+	})
+
+	It("reports the capacity of machine templates", func() {
+		tc := newTestCluster(ctx)
+		template := &infrav1.VultrMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0", Namespace: tc.namespace},
+			Spec: infrav1.VultrMachineTemplateSpec{Template: infrav1.VultrMachineTemplateResource{
+				Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
+			}},
+		}
+		Expect(k8sClient.Create(ctx, template)).To(Succeed())
+
+		waitForCapacity := func(cpu, memory string) {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(template), template)).To(Succeed())
+				g.Expect(template.Status.ObservedGeneration).To(Equal(template.Generation))
+				g.Expect(template.Status.Capacity.Cpu().String()).To(Equal(cpu))
+				g.Expect(template.Status.Capacity.Memory().String()).To(Equal(memory))
+			}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		}
+		waitForCapacity("2", "4Gi")
+
+		// The capacity follows plan changes.
+		template.Spec.Template.Spec.PlanID = "vc2-4c-8gb"
+		Expect(k8sClient.Update(ctx, template)).To(Succeed())
+		waitForCapacity("4", "8Gi")
+	})
+
+	It("reports preflight check failures before creating instances", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+
+		vultrAPI.SetPlanAvailable("ewr", "vc2-6c-16gb", false)
+		defer vultrAPI.SetPlanAvailable("ewr", "vc2-6c-16gb", true)
+		snapshotID := vultrAPI.AddSnapshot(govultr.Snapshot{Description: "kube"})
+
+		worker := tc.createMachine(ctx, "workload-md-preflight", false, func(m *infrav1.VultrMachine) {
+			m.Spec.PlanID = "vc2-6c-16gb"
+			m.Spec.Snapshot = snapshotID
+		})
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(conditions.GetReason(worker, infrav1.PreflightChecksPassedCondition)).To(Equal(infrav1.PlanNotAvailableReason))
+			g.Expect(conditions.GetSeverity(worker, infrav1.PreflightChecksPassedCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityError)))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(conditions.GetMessage(worker, infrav1.PreflightChecksPassedCondition)).To(ContainSubstring(`"vc2-6c-16gb" is not available in region "ewr"`))
+		for _, instance := range vultrAPI.Instances() {
+			Expect(instance.Label).NotTo(Equal(worker.Name))
+		}
+
+		// Fixing the spec resumes provisioning.
+		worker.Spec.PlanID = "vc2-4c-8gb"
+		Expect(k8sClient.Update(ctx, worker)).To(Succeed())
+		waitForMachineReady(ctx, worker)
+		Expect(conditions.IsTrue(worker, infrav1.PreflightChecksPassedCondition)).To(BeTrue())
+	})
+
+	It("reports API keys rejected by Vultr", func() {
+		vultrAPI.InjectFault(vultrfake.Fault{Method: http.MethodPost, Path: "/v2/load-balancers", StatusCode: http.StatusUnauthorized, Message: "Invalid API token."})
+		tc := newTestCluster(ctx)
+
+		Eventually(func(g Gomega) {
+			events := &corev1.EventList{}
+			g.Expect(k8sClient.List(ctx, events, client.InNamespace(tc.namespace))).To(Succeed())
+			g.Expect(events.Items).To(ContainElement(And(
+				HaveField("Reason", "InvalidCredentials"),
+				HaveField("InvolvedObject.Name", tc.vultrCluster.Name),
+			)))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+		Expect(tc.vultrCluster.Status.Ready).To(BeFalse())
+		Expect(tc.vultrCluster.Status.FailureReason).To(BeNil())
+
+		// The cluster recovers once the key is valid again.
+		vultrAPI.ClearFaults()
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+	})
+})
@@ -0,0 +1,33 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/vultr/cluster-api-provider-vultr/cloud/services"
+)
+
+// recordInvalidCredentials emits an event on obj if err was caused by the
+// Vultr API rejecting the API key.
+func recordInvalidCredentials(recorder record.EventRecorder, obj runtime.Object, err error) {
+	if services.IsUnauthorizedError(err) {
+		recorder.Event(obj, corev1.EventTypeWarning, "InvalidCredentials", "The Vultr API rejected the API key, rotate it or check its permissions")
+	}
+}
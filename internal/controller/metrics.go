@@ -0,0 +1,40 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// observeProvisioning records how long an object took to be provisioned,
+// from its creation until it became ready for the first time. Objects which
+// were provisioned before and only became ready again, e.g. after a stopped
+// instance was started, are not observed. Neither are objects whose Vultr
+// resource is older than themselves, as clusterctl move recreates objects
+// without their status.
+func observeProvisioning(observer prometheus.Observer, provisioned *bool, created metav1.Time, resourceCreated string) {
+	if provisioned != nil && *provisioned {
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, resourceCreated); err == nil && t.Before(created.Truncate(time.Second)) {
+		return
+	}
+	observer.Observe(time.Since(created.Time).Seconds())
+}
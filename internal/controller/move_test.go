@@ -0,0 +1,193 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+// resetObjectMeta clears the fields of obj which the API server sets, so that
+// it can be created again.
+func resetObjectMeta(obj client.Object) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+}
+
+// setOwnerUID points the owner references of obj with the given kind to uid.
+func setOwnerUID(obj client.Object, kind string, uid types.UID) {
+	refs := obj.GetOwnerReferences()
+	for i := range refs {
+		if refs[i].Kind == kind {
+			refs[i].UID = uid
+		}
+	}
+	obj.SetOwnerReferences(refs)
+}
+
+// deleteMoved deletes obj without running its finalizers, as clusterctl move
+// does with the objects it moved away.
+func deleteMoved(ctx context.Context, obj client.Object) {
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+	obj.SetFinalizers(nil)
+	Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+	Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+	waitForDeletion(ctx, obj)
+}
+
+// move plays clusterctl move: it pauses the cluster, replaces its objects
+// with copies which have new UIDs and no status, and unpauses it again.
+func (tc *testCluster) move(ctx context.Context, vultrMachines ...*infrav1.VultrMachine) {
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.cluster), tc.cluster)).To(Succeed())
+	tc.cluster.Spec.Paused = true
+	Expect(k8sClient.Update(ctx, tc.cluster)).To(Succeed())
+	isPaused := func(obj interface{ GetV1Beta2Conditions() []metav1.Condition }) bool {
+		return meta.IsStatusConditionTrue(obj.GetV1Beta2Conditions(), clusterv1.PausedV1Beta2Condition)
+	}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+		g.Expect(isPaused(tc.vultrCluster)).To(BeTrue())
+		for _, vultrMachine := range vultrMachines {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrMachine), vultrMachine)).To(Succeed())
+			g.Expect(isPaused(vultrMachine)).To(BeTrue())
+		}
+	}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+
+	cluster := tc.cluster.DeepCopy()
+	vultrCluster := tc.vultrCluster.DeepCopy()
+	var machines []*clusterv1.Machine
+	var movedVultrMachines []*infrav1.VultrMachine
+	for _, vultrMachine := range vultrMachines {
+		machine := &clusterv1.Machine{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrMachine), machine)).To(Succeed())
+		machines = append(machines, machine.DeepCopy())
+		movedVultrMachines = append(movedVultrMachines, vultrMachine.DeepCopy())
+		deleteMoved(ctx, vultrMachine)
+		deleteMoved(ctx, machine)
+	}
+	deleteMoved(ctx, tc.vultrCluster)
+	deleteMoved(ctx, tc.cluster)
+
+	resetObjectMeta(cluster)
+	cluster.Status = clusterv1.ClusterStatus{}
+	Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+	resetObjectMeta(vultrCluster)
+	setOwnerUID(vultrCluster, "Cluster", cluster.UID)
+	vultrCluster.Status = infrav1.VultrClusterStatus{}
+	Expect(k8sClient.Create(ctx, vultrCluster)).To(Succeed())
+	for i, machine := range machines {
+		resetObjectMeta(machine)
+		machine.Status = clusterv1.MachineStatus{}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		vultrMachine := movedVultrMachines[i]
+		resetObjectMeta(vultrMachine)
+		setOwnerUID(vultrMachine, "Machine", machine.UID)
+		vultrMachine.Status = infrav1.VultrMachineStatus{}
+		Expect(k8sClient.Create(ctx, vultrMachine)).To(Succeed())
+		*vultrMachines[i] = *vultrMachine
+	}
+	tc.cluster = cluster
+	tc.vultrCluster = vultrCluster
+
+	tc.cluster.Spec.Paused = false
+	Expect(k8sClient.Update(ctx, tc.cluster)).To(Succeed())
+}
+
+var _ = Describe("clusterctl move", func() {
+	ctx := context.Background()
+
+	It("re-derives the status of moved objects from Vultr", func() {
+		tc := newTestCluster(ctx)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		tc.markInfrastructureReady(ctx)
+		lbID := tc.vultrCluster.Spec.Network.APIServerLoadbalancers.ID
+		Expect(lbID).NotTo(BeEmpty())
+		endpoint := tc.vultrCluster.Spec.ControlPlaneEndpoint
+
+		controlPlane := tc.createMachine(ctx, "workload-move-control-plane", true)
+		worker := tc.createMachine(ctx, "workload-move-md-0", false, func(m *infrav1.VultrMachine) {
+			m.Spec.DataVolumes = []infrav1.DataVolume{{Label: "data", SizeGB: 20}}
+		})
+		controlPlaneID := waitForMachineReady(ctx, controlPlane)
+		workerID := waitForMachineReady(ctx, worker)
+		Expect(worker.Status.DataVolumes).To(HaveLen(1))
+		volumeID := worker.Status.DataVolumes[0].ID
+
+		tc.move(ctx, controlPlane, worker)
+		tc.markInfrastructureReady(ctx)
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tc.vultrCluster), tc.vultrCluster)).To(Succeed())
+			g.Expect(tc.vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		Expect(tc.vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID).To(Equal(lbID))
+		Expect(tc.vultrCluster.Spec.ControlPlaneEndpoint).To(Equal(endpoint))
+		Expect(waitForMachineReady(ctx, controlPlane)).To(Equal(controlPlaneID))
+		Expect(controlPlane.Status.Addresses).NotTo(BeEmpty())
+		Expect(waitForMachineReady(ctx, worker)).To(Equal(workerID))
+		Expect(worker.Status.Addresses).NotTo(BeEmpty())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			g.Expect(worker.Status.DataVolumes).To(ConsistOf(HaveField("ID", volumeID)))
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+
+		// Nothing was created for the moved objects.
+		Expect(tc.loadBalancers()).To(BeEmpty())
+		for _, name := range []string{controlPlane.Name, worker.Name} {
+			n := 0
+			for _, instance := range vultrAPI.Instances() {
+				if instance.Label == name {
+					n++
+				}
+			}
+			Expect(n).To(Equal(1), "instances of %s", name)
+		}
+		lb, ok := vultrAPI.LoadBalancer(lbID)
+		Expect(ok).To(BeTrue())
+		Expect(lb.Instances).To(ContainElement(controlPlaneID))
+
+		// The moved objects clean up the resources created before the move.
+		for _, vultrMachine := range []*infrav1.VultrMachine{worker, controlPlane} {
+			Expect(k8sClient.Delete(ctx, vultrMachine)).To(Succeed())
+			waitForDeletion(ctx, vultrMachine)
+		}
+		_, ok = vultrAPI.Instance(workerID)
+		Expect(ok).To(BeFalse())
+		_, ok = vultrAPI.Block(volumeID)
+		Expect(ok).To(BeFalse())
+		Expect(k8sClient.Delete(ctx, tc.vultrCluster)).To(Succeed())
+		waitForDeletion(ctx, tc.vultrCluster)
+		_, ok = vultrAPI.LoadBalancer(lbID)
+		Expect(ok).To(BeFalse())
+	})
+})
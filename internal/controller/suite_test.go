@@ -17,22 +17,32 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	infrastructurev1beta1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	infrastructurev1beta2 "github.com/vultr/cluster-api-provider-vultr/api/v1beta2"
+	vultrfake "github.com/vultr/cluster-api-provider-vultr/cloud/fake"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -43,6 +53,11 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 
+// vultrAPI is the fake Vultr API the reconcilers started by the suite talk to.
+var vultrAPI *vultrfake.Server
+
+var cancelManager context.CancelFunc
+
 func TestControllers(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -52,9 +67,18 @@ func TestControllers(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
+	// The Cluster API CRDs are copied from the module by make capi-crds.
+	capiCRDPath := filepath.Join("..", "..", "bin", "crd", "cluster-api")
+	if _, err := os.Stat(capiCRDPath); err != nil {
+		Skip(fmt.Sprintf("The Cluster API CRDs are missing in %s, run make capi-crds", capiCRDPath))
+	}
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			capiCRDPath,
+		},
 		ErrorIfCRDPathMissing: true,
 
 		// The BinaryAssetsDirectory is only required if you want to run the tests directly
@@ -75,16 +99,65 @@ var _ = BeforeSuite(func() {
 	err = infrastructurev1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = infrastructurev1beta2.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = clusterv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:scheme
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
+	By("starting the fake Vultr API")
+	vultrAPI = vultrfake.NewServer(vultrfake.Options{})
+	clientFactory := scope.ClientFactoryFunc(func() (scope.VultrAPIClients, error) {
+		return scope.NewVultrAPIClients(vultrAPI.VultrClient()), nil
+	})
+
+	By("starting the reconcilers")
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme.Scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	var ctx context.Context
+	ctx, cancelManager = context.WithCancel(context.Background())
+	Expect((&VultrClusterReconciler{
+		Client:        mgr.GetClient(),
+		Recorder:      mgr.GetEventRecorderFor("vultrcluster-controller"),
+		ClientFactory: clientFactory,
+	}).SetupWithManager(ctx, mgr, controller.Options{})).To(Succeed())
+	Expect((&VultrMachineReconciler{
+		Client:        mgr.GetClient(),
+		Recorder:      mgr.GetEventRecorderFor("vultrmachine-controller"),
+		ResyncPeriod:  time.Second,
+		ClientFactory: clientFactory,
+	}).SetupWithManager(ctx, mgr, controller.Options{})).To(Succeed())
+	Expect((&VultrMachineTemplateReconciler{
+		Client:        mgr.GetClient(),
+		Recorder:      mgr.GetEventRecorderFor("vultrmachinetemplate-controller"),
+		ClientFactory: clientFactory,
+	}).SetupWithManager(ctx, mgr, controller.Options{})).To(Succeed())
+
+	go func() {
+		defer GinkgoRecover()
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
 })
 
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
-	err := testEnv.Stop()
-	Expect(err).NotTo(HaveOccurred())
+	if cancelManager != nil {
+		cancelManager()
+	}
+	if vultrAPI != nil {
+		vultrAPI.Close()
+	}
+	if testEnv != nil {
+		Expect(testEnv.Stop()).To(Succeed())
+	}
 })
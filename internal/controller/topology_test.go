@@ -0,0 +1,219 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+)
+
+// topologyFieldOwner is the field manager the Cluster API topology controller
+// applies the objects it manages with.
+const topologyFieldOwner = "capi-topology"
+
+// applyTopologyVultrCluster creates or updates the VultrCluster of a
+// topology-managed Cluster from a VultrClusterTemplate, as the Cluster API
+// topology controller does: the template spec is applied with server-side
+// apply, and an empty control plane endpoint is left out so that the endpoint
+// set by the provider is not reset.
+func applyTopologyVultrCluster(ctx context.Context, cluster *clusterv1.Cluster, template *infrav1.VultrClusterTemplate) *infrav1.VultrCluster {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template.Spec.Template.Spec.DeepCopy())
+	Expect(err).NotTo(HaveOccurred())
+	if endpoint := template.Spec.Template.Spec.ControlPlaneEndpoint; endpoint.Host == "" && endpoint.Port == 0 {
+		delete(spec, "controlPlaneEndpoint")
+	}
+
+	desired := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	desired.SetAPIVersion(infrav1.GroupVersion.String())
+	desired.SetKind("VultrCluster")
+	desired.SetNamespace(cluster.Namespace)
+	desired.SetName(cluster.Name)
+	desired.SetLabels(map[string]string{
+		clusterv1.ClusterNameLabel:          cluster.Name,
+		clusterv1.ClusterTopologyOwnedLabel: "",
+	})
+	desired.SetAnnotations(map[string]string{
+		clusterv1.TemplateClonedFromNameAnnotation:      template.Name,
+		clusterv1.TemplateClonedFromGroupKindAnnotation: infrav1.GroupVersion.WithKind("VultrClusterTemplate").GroupKind().String(),
+	})
+	desired.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}})
+	Expect(k8sClient.Patch(ctx, desired, client.Apply, client.FieldOwner(topologyFieldOwner), client.ForceOwnership)).To(Succeed())
+
+	vultrCluster := &infrav1.VultrCluster{}
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), vultrCluster)).To(Succeed())
+	return vultrCluster
+}
+
+var _ = Describe("ClusterClass", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("rejects templates with fields set by the controllers", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "topology-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		clusterTemplate := func(name string, mutate func(*infrav1.VultrClusterSpec)) *infrav1.VultrClusterTemplate {
+			template := &infrav1.VultrClusterTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns.Name},
+				Spec: infrav1.VultrClusterTemplateSpec{Template: infrav1.VultrClusterTemplateResource{
+					Spec: infrav1.VultrClusterSpec{Region: "ewr"},
+				}},
+			}
+			mutate(&template.Spec.Template.Spec)
+			return template
+		}
+
+		err := k8sClient.Create(ctx, clusterTemplate("with-endpoint", func(spec *infrav1.VultrClusterSpec) {
+			spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "192.0.2.1", Port: 6443}
+		}))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+		Expect(err.Error()).To(ContainSubstring("controlPlaneEndpoint is set by the controller"))
+
+		err = k8sClient.Create(ctx, clusterTemplate("with-lb", func(spec *infrav1.VultrClusterSpec) {
+			spec.Network.APIServerLoadbalancers.ID = "lb-1"
+		}))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+
+		err = k8sClient.Create(ctx, &infrav1.VultrMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "with-provider-id", Namespace: ns.Name},
+			Spec: infrav1.VultrMachineTemplateSpec{Template: infrav1.VultrMachineTemplateResource{
+				Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb", ProviderID: ptr.To("vultr://1234")},
+			}},
+		})
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+
+		Expect(k8sClient.Create(ctx, clusterTemplate("valid", func(spec *infrav1.VultrClusterSpec) {
+			spec.Network.APIServerLoadbalancers.Label = "api"
+		}))).To(Succeed())
+	})
+
+	It("provisions topology-managed clusters", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "topology-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		clusterTemplate := &infrav1.VultrClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "vultr-cluster", Namespace: ns.Name},
+			Spec: infrav1.VultrClusterTemplateSpec{Template: infrav1.VultrClusterTemplateResource{
+				Spec: infrav1.VultrClusterSpec{Region: "ewr"},
+			}},
+		}
+		Expect(k8sClient.Create(ctx, clusterTemplate)).To(Succeed())
+		machineTemplate := &infrav1.VultrMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "vultr-worker", Namespace: ns.Name},
+			Spec: infrav1.VultrMachineTemplateSpec{Template: infrav1.VultrMachineTemplateResource{
+				Spec: infrav1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
+			}},
+		}
+		Expect(k8sClient.Create(ctx, machineTemplate)).To(Succeed())
+
+		templateRef := func(apiVersion, kind, name string) clusterv1.LocalObjectTemplate {
+			return clusterv1.LocalObjectTemplate{Ref: &corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: name, Namespace: ns.Name}}
+		}
+		clusterClass := &clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "vultr", Namespace: ns.Name},
+			Spec: clusterv1.ClusterClassSpec{
+				Infrastructure: templateRef(infrav1.GroupVersion.String(), "VultrClusterTemplate", clusterTemplate.Name),
+				ControlPlane: clusterv1.ControlPlaneClass{
+					LocalObjectTemplate: templateRef("controlplane.cluster.x-k8s.io/v1beta1", "KubeadmControlPlaneTemplate", "control-plane"),
+				},
+				Workers: clusterv1.WorkersClass{MachineDeployments: []clusterv1.MachineDeploymentClass{{
+					Class: "default-worker",
+					Template: clusterv1.MachineDeploymentClassTemplate{
+						Bootstrap:      templateRef("bootstrap.cluster.x-k8s.io/v1beta1", "KubeadmConfigTemplate", "worker"),
+						Infrastructure: templateRef(infrav1.GroupVersion.String(), "VultrMachineTemplate", machineTemplate.Name),
+					},
+				}}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, clusterClass)).To(Succeed())
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: ns.Name},
+			Spec: clusterv1.ClusterSpec{
+				Topology: &clusterv1.Topology{Class: clusterClass.Name, Version: "v1.31.0"},
+				InfrastructureRef: &corev1.ObjectReference{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "VultrCluster",
+					Name:       "workload",
+					Namespace:  ns.Name,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: ns.Name},
+			Data:       map[string][]byte{"value": []byte("#cloud-config\nruncmd:\n  - kubeadm join\n")},
+		})).To(Succeed())
+
+		vultrCluster := applyTopologyVultrCluster(ctx, cluster, clusterTemplate)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrCluster), vultrCluster)).To(Succeed())
+			g.Expect(vultrCluster.Status.Ready).To(BeTrue())
+		}, lifecycleTimeout, lifecycleInterval).Should(Succeed())
+		endpoint := vultrCluster.Spec.ControlPlaneEndpoint
+		Expect(endpoint.Host).NotTo(BeEmpty())
+		lbID := vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID
+		Expect(lbID).NotTo(BeEmpty())
+
+		// Applying the template again, as the topology controller does on
+		// every reconcile, keeps the endpoint and the load balancer.
+		vultrCluster = applyTopologyVultrCluster(ctx, cluster, clusterTemplate)
+		Expect(vultrCluster.Spec.ControlPlaneEndpoint).To(Equal(endpoint))
+		Expect(vultrCluster.Spec.Network.APIServerLoadbalancers.ID).To(Equal(lbID))
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrCluster), vultrCluster)).To(Succeed())
+			g.Expect(vultrCluster.Status.Ready).To(BeTrue())
+			g.Expect(vultrCluster.Status.Network.APIServerLoadbalancersRef.ResourceID).To(Equal(lbID))
+		}, 2*time.Second, lifecycleInterval).Should(Succeed())
+
+		// Machines of the worker class are created from the machine template.
+		tc := &testCluster{namespace: ns.Name, cluster: cluster, vultrCluster: vultrCluster}
+		tc.markInfrastructureReady(ctx)
+		worker := tc.createMachine(ctx, "workload-md-0-abcde", false, func(m *infrav1.VultrMachine) {
+			m.Spec = *machineTemplate.Spec.Template.Spec.DeepCopy()
+		})
+		waitForMachineReady(ctx, worker)
+
+		Expect(k8sClient.Delete(ctx, worker)).To(Succeed())
+		waitForDeletion(ctx, worker)
+		Expect(k8sClient.Delete(ctx, vultrCluster)).To(Succeed())
+		waitForDeletion(ctx, vultrCluster)
+		_, ok := vultrAPI.LoadBalancer(lbID)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -27,8 +27,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
 	clusterutil "sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -41,9 +43,12 @@ import (
 
 	"github.com/pkg/errors"
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
 	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
 	"github.com/vultr/cluster-api-provider-vultr/cloud/services"
 	"github.com/vultr/cluster-api-provider-vultr/util/reconciler"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // VultrClusterReconciler reconciles a VultrCluster object
@@ -52,23 +57,30 @@ type VultrClusterReconciler struct {
 	ReconcileTimeout time.Duration
 	Recorder         record.EventRecorder
 	WatchFilterValue string
+	// Intervals are the delays after which load balancers which are being
+	// provisioned are checked again.
+	Intervals reconciler.Intervals
+	// ClientFactory creates the Vultr API clients. The clients are created
+	// from the environment when it is nil.
+	ClientFactory scope.ClientFactory
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *VultrClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, _ controller.Options) error {
+func (r *VultrClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.VultrCluster{}).
-		WithEventFilter(predicates.ResourceNotPaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx))). // don't queue reconcile if resource is paused
+		WithOptions(options).
 		Watches(
-			&clusterv1.Cluster{}, // Add a watch on clusterv1.Cluster object for unpause notifications.
+			&clusterv1.Cluster{}, // Add a watch on clusterv1.Cluster object for pause and unpause notifications.
 			handler.EnqueueRequestsFromMapFunc(clusterutil.ClusterToInfrastructureMapFunc(
 				ctx,
 				infrav1.GroupVersion.WithKind("VultrCluster"),
 				mgr.GetClient(),
 				&infrav1.VultrCluster{},
 			)),
-			builder.WithPredicates(predicates.ClusterUnpaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx))), // Filter for unpaused clusters
+			builder.WithPredicates(predicates.ClusterPausedTransitions(mgr.GetScheme(), ctrl.LoggerFrom(ctx))), // Filter for pause transitions so the Paused condition is kept up to date
 		).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 	if err != nil {
 		return errors.Wrapf(err, "failed to build controller")
@@ -85,7 +97,17 @@ func (r *VultrClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartSpan(ctx, "VultrClusterReconciler.Reconcile",
+		attribute.String("namespace", req.Namespace),
+		attribute.String("name", req.Name),
+	)
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	log := ctrl.LoggerFrom(ctx)
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		log = log.WithValues("traceID", traceID)
+		ctx = ctrl.LoggerInto(ctx, log)
+	}
 	logger := ctrl.LoggerFrom(ctx).WithName("VultrClusterReconciler").WithValues("name", req.NamespacedName.String()) //nolint:staticcheck
 
 	// Fetch the VultrCluster.
@@ -99,6 +121,7 @@ func (r *VultrClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		log.Error(err, "Unable to fetch VultrCluster resource")
 		return ctrl.Result{}, err
 	}
+	defer func() { recordInvalidCredentials(r.Recorder, vultrCluster, reterr) }()
 
 	// Fetch the Cluster.
 	cluster, err := clusterutil.GetOwnerCluster(ctx, r.Client, vultrCluster.ObjectMeta)
@@ -110,17 +133,25 @@ func (r *VultrClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return reconcile.Result{}, client.IgnoreNotFound(err)
 	}
 	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, vultrCluster) {
-		log.Info("VultrCluster or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+	if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, vultrCluster); err != nil || isPaused || requeue {
+		if isPaused {
+			log.Info("VultrCluster or linked Cluster is marked as paused. Won't reconcile")
+		}
+		return ctrl.Result{}, err
+	}
+
+	clients, err := scope.NewClients(r.ClientFactory)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// Create the cluster scope.
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:       r.Client,
-		Logger:       log,
-		Cluster:      cluster,
-		VultrCluster: vultrCluster,
+		VultrAPIClients: clients,
+		Client:          r.Client,
+		Logger:          log,
+		Cluster:         cluster,
+		VultrCluster:    vultrCluster,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %v", err)
@@ -146,30 +177,70 @@ func (r *VultrClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 func (r *VultrClusterReconciler) reconcileNormal(ctx context.Context, clusterScope *scope.ClusterScope) (res ctrl.Result, reterr error) {
 	clusterScope.Info("Reconciling VultrCluster")
 	vultrcluster := clusterScope.VultrCluster
+
+	if vultrcluster.Status.FailureReason != nil || vultrcluster.Status.FailureMessage != nil {
+		clusterScope.Info("Error state detected, skipping reconciliation")
+		return reconcile.Result{}, nil
+	}
+
 	// If the VultrCluster doesn't have finalizer, add it.
 	controllerutil.AddFinalizer(vultrcluster, infrav1.ClusterFinalizer)
 
 	vlbservice := services.NewService(ctx, clusterScope)
+
 	apiServerLoadbalancer := clusterScope.APIServerLoadbalancers()
 	apiServerLoadbalancer.ApplyDefaults()
 
 	apiServerLoadbalancerRef := clusterScope.APIServerLoadbalancersRef()
-	vlbID := apiServerLoadbalancerRef.ResourceID
-
-	if apiServerLoadbalancer.ID != "" {
-		vlbID = apiServerLoadbalancer.ID
-	}
-
-	loadbalancer, err := vlbservice.GetLoadBalancer(vlbID)
+	loadbalancerID := clusterScope.APIServerLoadbalancerID()
+	loadbalancer, err := vlbservice.GetLoadBalancer(loadbalancerID)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if loadbalancer == nil && loadbalancerID != "" && !vultrcluster.Spec.ControlPlaneEndpoint.IsZero() {
+		// A new load balancer would not get the address of the control plane
+		// endpoint, which the nodes and kubeconfigs of the cluster use.
+		msg := fmt.Sprintf("Load balancer %s of the control plane endpoint %s was deleted outside of Cluster API", loadbalancerID, vultrcluster.Spec.ControlPlaneEndpoint.Host)
+		conditions.MarkFalse(vultrcluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerNotFoundReason, clusterv1.ConditionSeverityError, "%s", msg)
+		r.Recorder.Event(vultrcluster, corev1.EventTypeWarning, "LoadBalancerNotFound", msg)
+		return reconcile.Result{}, nil
+	}
+
 	if loadbalancer == nil {
+		// The VPC is only looked up before the load balancer is created.
+		if vpcID := vultrcluster.Spec.VPCID; vpcID != "" {
+			vpc, err := vlbservice.GetVPC(vpcID)
+			if err != nil {
+				conditions.MarkFalse(vultrcluster, infrav1.VPCReadyCondition, infrav1.VPCLookupFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+				return reconcile.Result{}, err
+			}
+			if vpc == nil {
+				conditions.MarkFalse(vultrcluster, infrav1.VPCReadyCondition, infrav1.VPCNotFoundReason, clusterv1.ConditionSeverityError, "VPC %q not found", vpcID)
+				r.Recorder.Eventf(vultrcluster, corev1.EventTypeWarning, "VPCNotFound", "VPC %s not found", vpcID)
+				// Only a change of the spec can fix it.
+				return reconcile.Result{}, nil
+			}
+			conditions.MarkTrue(vultrcluster, infrav1.VPCReadyCondition)
+		} else {
+			conditions.Delete(vultrcluster, infrav1.VPCReadyCondition)
+		}
+
 		loadbalancer, err = vlbservice.CreateLoadBalancer(apiServerLoadbalancer)
 		lbPayload, _ := json.Marshal(apiServerLoadbalancer)
 		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "failed to create load balancers for VultrCluster %s/%s, payload: %s", vultrcluster.Namespace, vultrcluster.Name, string(lbPayload))
+			err = errors.Wrapf(err, "failed to create load balancers for VultrCluster %s/%s, payload: %s", vultrcluster.Namespace, vultrcluster.Name, string(lbPayload))
+			r.Recorder.Event(vultrcluster, corev1.EventTypeWarning, "LoadBalancerCreatingError", err.Error())
+			if services.IsTerminalError(err) {
+				// Retrying will not help, surface the error and stop reconciling.
+				clusterScope.SetFailureReason(capierrors.CreateClusterError)
+				clusterScope.SetFailureMessage(err)
+				conditions.MarkFalse(vultrcluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerProvisionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+				return reconcile.Result{}, nil
+			}
+			// Transient errors are requeued with the controller's exponential backoff.
+			conditions.MarkFalse(vultrcluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerProvisionFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, err
 		}
 
 		r.Recorder.Eventf(vultrcluster, corev1.EventTypeNormal, "LoadBalancerCreated", "Created new load balancers - %s", loadbalancer.Label)
@@ -181,10 +252,12 @@ func (r *VultrClusterReconciler) reconcileNormal(ctx context.Context, clusterSco
 
 	if apiServerLoadbalancerRef.ResourcePowerStatus != infrav1.PowerStatusRunning && loadbalancer.IPV4 == "" {
 		clusterScope.Info("Waiting on API server Global IP Address")
-		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+		conditions.MarkFalse(vultrcluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerProvisioningReason, clusterv1.ConditionSeverityInfo, "Waiting for load balancer %s to get an IP address", loadbalancer.ID)
+		return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
 	}
 
 	r.Recorder.Eventf(vultrcluster, corev1.EventTypeNormal, "LoadBalancerReady", "LoadBalancer got an IP Address - %s", loadbalancer.IPV4)
+	conditions.MarkTrue(vultrcluster, infrav1.LoadBalancerReadyCondition)
 
 	controlPlaneEndpoint := loadbalancer.IPV4
 
@@ -193,6 +266,9 @@ func (r *VultrClusterReconciler) reconcileNormal(ctx context.Context, clusterSco
 		Port: int32(apiServerLoadbalancer.HealthCheck.Port),
 	})
 
+	observeProvisioning(metrics.LoadBalancerProvisioningSeconds.WithLabelValues(clusterScope.Region()),
+		clusterScope.Provisioned(), vultrcluster.CreationTimestamp, loadbalancer.DateCreated)
+
 	clusterScope.Info("Set VultrCluster status to ready")
 	clusterScope.SetReady()
 	clusterScope.VultrCluster.Status.Ready = true
@@ -203,12 +279,10 @@ func (r *VultrClusterReconciler) reconcileNormal(ctx context.Context, clusterSco
 func (r *VultrClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) { //nolint: unparam
 	clusterScope.Info("Reconciling delete VultrCluster")
 	vultrcluster := clusterScope.VultrCluster
+	conditions.MarkFalse(vultrcluster, infrav1.LoadBalancerReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 
 	vlbservice := services.NewService(ctx, clusterScope)
-	apiServerLoadbalancerRef := clusterScope.APIServerLoadbalancersRef()
-	vlbID := apiServerLoadbalancerRef.ResourceID
-
-	loadbalancer, err := vlbservice.GetLoadBalancer(vlbID)
+	loadbalancer, err := vlbservice.GetLoadBalancer(clusterScope.APIServerLoadbalancerID())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -19,15 +19,19 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -39,9 +43,14 @@ import (
 
 	"github.com/pkg/errors"
 	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/metrics"
 	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
 	"github.com/vultr/cluster-api-provider-vultr/cloud/services"
+	"github.com/vultr/cluster-api-provider-vultr/util/logging"
 	"github.com/vultr/cluster-api-provider-vultr/util/reconciler"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+	"github.com/vultr/govultr/v3"
+	"go.opentelemetry.io/otel/attribute"
 	capierrors "sigs.k8s.io/cluster-api/errors" //nolint:staticcheck
 )
 
@@ -50,6 +59,20 @@ type VultrMachineReconciler struct {
 	client.Client
 	Recorder         record.EventRecorder
 	ReconcileTimeout time.Duration
+	// ResyncPeriod is how often machines with an active instance are
+	// reconciled to detect changes made outside of Cluster API, e.g. an
+	// instance deleted in the Vultr console.
+	ResyncPeriod time.Duration
+	// Intervals are the delays after which instances which are changing, or
+	// waiting for a change outside of the provider, are checked again.
+	Intervals reconciler.Intervals
+	// WatchFilterValue is the value of the cluster.x-k8s.io/watch-filter
+	// label of the objects to reconcile. All objects are reconciled when it
+	// is empty.
+	WatchFilterValue string
+	// ClientFactory creates the Vultr API clients. The clients are created
+	// from the environment when it is nil.
+	ClientFactory scope.ClientFactory
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vultrmachines,verbs=get;list;watch;create;update;patch;delete
@@ -65,7 +88,17 @@ func (r *VultrMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
 	defer cancel()
 
+	ctx, span := tracing.StartSpan(ctx, "VultrMachineReconciler.Reconcile",
+		attribute.String("namespace", req.Namespace),
+		attribute.String("name", req.Name),
+	)
+	defer func() { tracing.EndSpan(span, reterr) }()
+
 	log := ctrl.LoggerFrom(ctx)
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		log = log.WithValues("traceID", traceID)
+		ctx = ctrl.LoggerInto(ctx, log)
+	}
 
 	// Fetch the VultrMachine.
 	vultrMachine := &infrav1.VultrMachine{}
@@ -75,6 +108,7 @@ func (r *VultrMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 		return reconcile.Result{}, err
 	}
+	defer func() { recordInvalidCredentials(r.Recorder, vultrMachine, reterr) }()
 
 	// Fetch the Machine.
 	machine, err := util.GetOwnerMachine(ctx, r.Client, vultrMachine.ObjectMeta)
@@ -105,17 +139,27 @@ func (r *VultrMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, vultrCluster) && !scope.MachineOnly() {
-		log.Info("VultrMachine or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	if !scope.MachineOnly() {
+		if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, vultrMachine); err != nil || isPaused || requeue {
+			if isPaused {
+				log.Info("VultrMachine or linked Cluster is marked as paused. Won't reconcile")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	clients, err := scope.NewClients(r.ClientFactory)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// Create the cluster scope.
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:       r.Client,
-		Logger:       log,
-		Cluster:      cluster,
-		VultrCluster: vultrCluster,
+		VultrAPIClients: clients,
+		Client:          r.Client,
+		Logger:          log,
+		Cluster:         cluster,
+		VultrCluster:    vultrCluster,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %v", err)
@@ -163,19 +207,32 @@ func (r *VultrMachineReconciler) reconcileNormal(ctx context.Context, machineSco
 
 	if !machineScope.Cluster.Status.InfrastructureReady {
 		machineScope.Info("Cluster infrastructure is not ready yet")
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
 		return reconcile.Result{}, nil
 	}
 
 	// Make sure bootstrap data is available and populated.
 	if machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
 		machineScope.Info("Bootstrap data secret reference is not yet available")
+		conditions.MarkFalse(vultrmachine, infrav1.BootstrapDataAvailableCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
 		return reconcile.Result{}, nil
 	}
+	conditions.MarkTrue(vultrmachine, infrav1.BootstrapDataAvailableCondition)
 
 	r.Recorder.Event(vultrmachine, corev1.EventTypeNormal, "InstanceServiceInitializing", "Initializing instance service")
 	instancesvc := services.NewService(ctx, clusterScope)
 	r.Recorder.Event(vultrmachine, corev1.EventTypeNormal, "InstanceServiceInitialized", "Instance service initialized")
 
+	if machineScope.GetInstanceID() == "" {
+		if result, passed, err := r.reconcilePreflight(machineScope, instancesvc); !passed {
+			return result, err
+		}
+	}
+
+	if machineScope.IsBareMetal() {
+		return r.reconcileBareMetal(machineScope, instancesvc)
+	}
+
 	machineID := machineScope.GetInstanceID()
 	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceRetrieving", "Retrieving instance with ID %s", machineID)
 	instance, err := instancesvc.GetInstance(machineID)
@@ -183,32 +240,60 @@ func (r *VultrMachineReconciler) reconcileNormal(ctx context.Context, machineSco
 		return reconcile.Result{}, err
 	}
 
+	if instance == nil && machineID != "" {
+		r.failDeletedInstance(machineScope, "Instance", machineID)
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.reconcileDataVolumes(machineScope, instancesvc, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if instance == nil {
 		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceCreating", "Instance is nil attempting create %v", instance)
 		instance, err = instancesvc.CreateInstance(machineScope)
 		instancePayload, _ := json.Marshal(vultrmachine)
-		machineScope.Info("Created new instance", "payload", string(instancePayload))
 		if err != nil {
-			err = errors.Errorf("Failed to create instance for VultrMachine %s/%s: %v", vultrmachine.Namespace, vultrmachine.Name, err)
+			err = errors.Wrapf(err, "Failed to create instance for VultrMachine %s/%s", vultrmachine.Namespace, vultrmachine.Name)
 			r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "InstanceCreatingError", err.Error())
-			machineScope.SetInstanceServerState(infrav1.ServerStateError)
+			if services.IsTerminalError(err) {
+				// Retrying will not help, surface the error and stop reconciling.
+				machineScope.SetInstanceServerState(infrav1.ServerStateError)
+				machineScope.SetFailureReason(capierrors.CreateMachineError)
+				machineScope.SetFailureMessage(err)
+				conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+				return reconcile.Result{}, nil
+			}
+			// Transient errors are requeued with the controller's exponential backoff.
+			conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 			return reconcile.Result{}, err
 		}
+		machineScope.SetAppliedAdditionalTags(machineScope.AdditionalTags())
+		machineScope.Info("Created new instance", logging.InstanceIDKey, instance.ID)
 		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceCreated", "Created new instance instance - %s, payload: %s", instance.Label, string(instancePayload))
 	}
 
+	conditions.MarkTrue(vultrmachine, infrav1.InstanceProvisionedCondition)
 	machineScope.SetProviderID(instance.ID)
 	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "SetInstanceStatus", "Setting Instance Status %s", instance.Label)
 	machineScope.SetInstanceStatus(infrav1.SubscriptionStatus(instance.Status))
+	machineScope.SetInstancePowerStatus(infrav1.PowerStatus(instance.PowerStatus))
+	machineScope.SetInstanceServerState(infrav1.ServerState(instance.ServerStatus))
 
 	if strings.Contains(instance.Label, "control-plane") {
 		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "AddInstanceToVLB", "Instance %s is a control plane node, adding to VLB", instance.ID)
-		err := instancesvc.AddInstanceToVLB(clusterScope.APIServerLoadbalancersRef().ResourceID, instance.ID)
+		attached, err := instancesvc.AddInstanceToVLB(clusterScope.APIServerLoadbalancerID(), instance.ID)
 		if err != nil {
 			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "AddInstanceToVLBFailed", "Failed to add instance %s to VLB: %v", instance.ID, err)
+			conditions.MarkFalse(vultrmachine, infrav1.LoadBalancerAttachedCondition, infrav1.LoadBalancerAttachFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 			return reconcile.Result{}, errors.Wrap(err, "failed to add instance to VLB")
 		}
+		if !attached {
+			conditions.MarkFalse(vultrmachine, infrav1.LoadBalancerAttachedCondition, infrav1.LoadBalancerAttachPendingReason, clusterv1.ConditionSeverityInfo, "Waiting for the load balancer to become active")
+			return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
+		}
 		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "AddInstanceToVLBSuccess", "Successfully added instance %s to VLB", instance.ID)
+		conditions.MarkTrue(vultrmachine, infrav1.LoadBalancerAttachedCondition)
 	}
 
 	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "GetInstanceAddress", "Getting address for instance %s", instance.ID)
@@ -221,31 +306,632 @@ func (r *VultrMachineReconciler) reconcileNormal(ctx context.Context, machineSco
 	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "GetInstanceAddressSuccess", "Successfully retrieved address for instance %s: %v", instance.ID, addrs)
 	machineScope.SetAddresses(addrs)
 
+	if r.reconcileAction(machineScope, instancesvc, instance) {
+		return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
+	}
+
+	if result, done, err := r.reconcilePlan(machineScope, instancesvc, instance); done {
+		return result, err
+	}
+
 	switch infrav1.SubscriptionStatus(instance.Status) {
 	case infrav1.SubscriptionStatusPending:
-		machineScope.Info("Machine instance is pending", "instance-id", machineScope.GetInstanceID())
-		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		machineScope.Info("Machine instance is pending", logging.InstanceIDKey, machineScope.GetInstanceID())
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstancePendingReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
 	case infrav1.SubscriptionStatusActive:
-		machineScope.Info("Machine instance is active", "instance-id", machineScope.GetInstanceID())
+		if infrav1.ServerState(instance.ServerStatus) == infrav1.ServerStateLocked {
+			machineScope.Info("Machine instance is locked", logging.InstanceIDKey, machineScope.GetInstanceID())
+			machineScope.SetNotReady()
+			conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceLockedReason, clusterv1.ConditionSeverityWarning, "Instance is locked")
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "InstanceLocked", "Instance %s is locked", instance.ID)
+			return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, nil
+		}
+		if infrav1.PowerStatus(instance.PowerStatus) == infrav1.PowerStatusStopped {
+			return r.reconcileStoppedInstance(machineScope, instancesvc, instance)
+		}
+		machineScope.Info("Machine instance is active", logging.InstanceIDKey, machineScope.GetInstanceID())
+		conditions.MarkTrue(vultrmachine, infrav1.InstanceRunningCondition)
+		if err := r.reconcileTags(machineScope, instancesvc, instance.ID, instance.Tags, instancesvc.UpdateInstanceTags); err != nil {
+			return reconcile.Result{}, err
+		}
+		if result, done, err := r.attachDataVolumes(machineScope, instancesvc, instance); done {
+			return result, err
+		}
+		observeProvisioning(metrics.MachineProvisioningSeconds.WithLabelValues(vultrmachine.Spec.Region, machineScope.Role()),
+			machineScope.Provisioned(), vultrmachine.CreationTimestamp, instance.DateCreated)
 		machineScope.SetReady()
+		// Keep watching the instance for changes made outside of Cluster API.
+		return reconcile.Result{RequeueAfter: reconciler.DefaultedResyncPeriod(r.ResyncPeriod)}, nil
+	case infrav1.SubscriptionStatusClosed:
+		err := errors.Errorf("Instance %s subscription has been closed", instance.ID)
+		machineScope.SetNotReady()
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(err)
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "InstanceClosed", err.Error())
 		return reconcile.Result{}, nil
 	default:
+		// Suspended or unknown subscriptions may recover without intervention.
+		machineScope.Info("Machine instance is not active", logging.InstanceIDKey, machineScope.GetInstanceID(), "status", instance.Status)
+		machineScope.SetNotReady()
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceNotActiveReason, clusterv1.ConditionSeverityWarning, "Instance status is %q", instance.Status)
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "InstanceNotActive", "Instance %s has status %q", instance.ID, instance.Status)
+		return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, nil
+	}
+}
+
+// reconcilePreflight checks the spec against the Vultr API before the
+// instance is created, so that mistakes are reported with an actionable
+// message instead of failing instance creation over and over. It reports
+// whether the checks passed. Failures only a change of the spec can fix are
+// not retried, including a missing VPC or firewall group. The VPC and
+// firewall group are only looked up here, as they are not changed on
+// existing instances.
+func (r *VultrMachineReconciler) reconcilePreflight(machineScope *scope.MachineScope, instancesvc *services.Service) (reconcile.Result, bool, error) {
+	vultrmachine := machineScope.VultrMachine
+	spec := vultrmachine.Spec
+
+	var vpc *govultr.VPC
+	if vpcID := vultrmachine.Spec.VPCID; vpcID != "" {
+		var err error
+		vpc, err = instancesvc.GetVPC(vpcID)
+		if err != nil {
+			conditions.MarkFalse(vultrmachine, infrav1.VPCReadyCondition, infrav1.VPCLookupFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, false, err
+		}
+		if vpc == nil {
+			conditions.MarkFalse(vultrmachine, infrav1.VPCReadyCondition, infrav1.VPCNotFoundReason, clusterv1.ConditionSeverityError, "VPC %q not found", vpcID)
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "VPCNotFound", "VPC %s not found", vpcID)
+			return reconcile.Result{}, false, nil
+		}
+		conditions.MarkTrue(vultrmachine, infrav1.VPCReadyCondition)
+	} else {
+		conditions.Delete(vultrmachine, infrav1.VPCReadyCondition)
+	}
+
+	if firewallGroupID := vultrmachine.Spec.FirewallGroupID; firewallGroupID != "" {
+		group, err := instancesvc.GetFirewallGroup(firewallGroupID)
+		if err != nil {
+			conditions.MarkFalse(vultrmachine, infrav1.FirewallReadyCondition, infrav1.FirewallGroupLookupFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, false, err
+		}
+		if group == nil {
+			conditions.MarkFalse(vultrmachine, infrav1.FirewallReadyCondition, infrav1.FirewallGroupNotFoundReason, clusterv1.ConditionSeverityError, "Firewall group %q not found", firewallGroupID)
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "FirewallGroupNotFound", "Firewall group %s not found", firewallGroupID)
+			return reconcile.Result{}, false, nil
+		}
+		conditions.MarkTrue(vultrmachine, infrav1.FirewallReadyCondition)
+	} else {
+		conditions.Delete(vultrmachine, infrav1.FirewallReadyCondition)
+	}
+
+	fail := func(reason, format string, args ...any) (reconcile.Result, bool, error) {
+		msg := fmt.Sprintf(format, args...)
+		conditions.MarkFalse(vultrmachine, infrav1.PreflightChecksPassedCondition, reason, clusterv1.ConditionSeverityError, "%s", msg)
+		r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "PreflightCheckFailed", msg)
+		return reconcile.Result{}, false, nil
+	}
+	lookupFailed := func(err error) (reconcile.Result, bool, error) {
+		conditions.MarkFalse(vultrmachine, infrav1.PreflightChecksPassedCondition, infrav1.PreflightLookupFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return reconcile.Result{}, false, err
+	}
+
+	plans, err := instancesvc.GetAvailablePlans(spec.Region)
+	if err != nil {
+		return lookupFailed(err)
+	}
+	if plans == nil {
+		return fail(infrav1.RegionNotFoundReason, "Region %q does not exist, set spec.region to a Vultr region", spec.Region)
+	}
+	switch bareMetalPlan := strings.HasPrefix(spec.PlanID, services.BareMetalPlanPrefix); {
+	case machineScope.IsBareMetal() && !bareMetalPlan:
+		return fail(infrav1.PlanNotAvailableReason, "Plan %q is not a bare metal plan, bare metal machines need a %s plan", spec.PlanID, services.BareMetalPlanPrefix)
+	case !machineScope.IsBareMetal() && bareMetalPlan:
+		return fail(infrav1.PlanNotAvailableReason, "Plan %q is a bare metal plan, set spec.machineType to %s to use it", spec.PlanID, infrav1.MachineTypeBareMetal)
+	}
+	if !slices.Contains(plans, spec.PlanID) {
+		return fail(infrav1.PlanNotAvailableReason, "Plan %q is not available in region %q, choose another plan or region", spec.PlanID, spec.Region)
+	}
+
+	if spec.Snapshot != "" {
+		snapshot, err := instancesvc.GetSnapshot(spec.Snapshot)
+		if err != nil {
+			return lookupFailed(err)
+		}
+		if snapshot == nil {
+			return fail(infrav1.SnapshotNotFoundReason, "Snapshot %q does not exist, set spec.snapshot_id to an existing snapshot", spec.Snapshot)
+		}
+		if snapshot.Status != services.SnapshotStatusComplete {
+			// Snapshots complete on their own, wait for it.
+			conditions.MarkFalse(vultrmachine, infrav1.PreflightChecksPassedCondition, infrav1.SnapshotNotReadyReason, clusterv1.ConditionSeverityWarning,
+				"Snapshot %q is %s, waiting for it to complete", spec.Snapshot, snapshot.Status)
+			return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, false, nil
+		}
+	}
+
+	if vpc != nil && vpc.Region != spec.Region {
+		return fail(infrav1.VPCRegionMismatchReason, "VPC %q is in region %q, not in region %q of the machine", vpc.ID, vpc.Region, spec.Region)
+	}
+
+	conditions.MarkTrue(vultrmachine, infrav1.PreflightChecksPassedCondition)
+	return reconcile.Result{}, true, nil
+}
+
+// failDeletedInstance fails a machine whose instance or bare metal server was
+// created before and someone else deleted it, so that MachineHealthCheck
+// remediation replaces it.
+func (r *VultrMachineReconciler) failDeletedInstance(machineScope *scope.MachineScope, kind, id string) {
+	vultrmachine := machineScope.VultrMachine
+	err := errors.Errorf("%s %s was deleted outside of Cluster API", kind, id)
+	machineScope.Info("Machine instance was deleted", logging.InstanceIDKey, id)
+	machineScope.SetNotReady()
+	machineScope.SetFailureReason(capierrors.UpdateMachineError)
+	machineScope.SetFailureMessage(err)
+	conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceDeletedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceDeletedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "InstanceMissing", err.Error())
+}
+
+// reconcileBareMetal reconciles a machine backed by a bare metal server.
+// Bare metal servers are only created, watched and deleted: actions, start
+// policies, plan upgrades and data volumes only apply to instances.
+func (r *VultrMachineReconciler) reconcileBareMetal(machineScope *scope.MachineScope, instancesvc *services.Service) (reconcile.Result, error) {
+	vultrmachine := machineScope.VultrMachine
+
+	if machineScope.IsControlPlane() {
+		// Vultr load balancers only balance instances.
+		err := errors.New("bare metal machines cannot be control plane machines")
+		machineScope.SetFailureReason(capierrors.CreateMachineError)
+		machineScope.SetFailureMessage(err)
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	serverID := machineScope.GetInstanceID()
+	server, err := instancesvc.GetBareMetal(serverID)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if server == nil && serverID != "" {
+		r.failDeletedInstance(machineScope, "Bare metal server", serverID)
+		return reconcile.Result{}, nil
+	}
+
+	if server == nil {
+		server, err = instancesvc.CreateBareMetal(machineScope)
+		if err != nil {
+			err = errors.Wrapf(err, "Failed to create bare metal server for VultrMachine %s/%s", vultrmachine.Namespace, vultrmachine.Name)
+			r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "InstanceCreatingError", err.Error())
+			if services.IsTerminalError(err) {
+				machineScope.SetFailureReason(capierrors.CreateMachineError)
+				machineScope.SetFailureMessage(err)
+				conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+				return reconcile.Result{}, nil
+			}
+			conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, err
+		}
+		machineScope.SetAppliedAdditionalTags(machineScope.AdditionalTags())
+		machineScope.Info("Created new bare metal server", logging.InstanceIDKey, server.ID)
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceCreated", "Created new bare metal server %s", server.ID)
+	}
+
+	conditions.MarkTrue(vultrmachine, infrav1.InstanceProvisionedCondition)
+	machineScope.SetProviderID(server.ID)
+	machineScope.SetInstanceStatus(infrav1.SubscriptionStatus(server.Status))
+
+	switch infrav1.SubscriptionStatus(server.Status) {
+	case infrav1.SubscriptionStatusPending:
+		machineScope.Info("Machine bare metal server is pending", logging.InstanceIDKey, server.ID)
+		machineScope.SetAddresses(instancesvc.GetBareMetalAddress(server, ""))
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstancePendingReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
+	case infrav1.SubscriptionStatusActive:
+		var internalIP string
+		if vpcID := vultrmachine.Spec.VPCID; vpcID != "" {
+			// Bare metal servers are attached to their VPC once they are active.
+			internalIP, err = instancesvc.GetBareMetalVPCAddress(server.ID, vpcID)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if internalIP == "" {
+				if err := instancesvc.AttachBareMetalVPC(server.ID, vpcID); err != nil {
+					conditions.MarkFalse(vultrmachine, infrav1.VPCReadyCondition, infrav1.VPCLookupFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+					return reconcile.Result{}, err
+				}
+				r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "VPCAttached", "Attached bare metal server %s to VPC %s", server.ID, vpcID)
+				return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
+			}
+		}
+		machineScope.SetAddresses(instancesvc.GetBareMetalAddress(server, internalIP))
+
+		machineScope.Info("Machine bare metal server is active", logging.InstanceIDKey, server.ID)
+		conditions.MarkTrue(vultrmachine, infrav1.InstanceRunningCondition)
+		if err := r.reconcileTags(machineScope, instancesvc, server.ID, server.Tags, instancesvc.UpdateBareMetalTags); err != nil {
+			return reconcile.Result{}, err
+		}
+		observeProvisioning(metrics.MachineProvisioningSeconds.WithLabelValues(vultrmachine.Spec.Region, machineScope.Role()),
+			machineScope.Provisioned(), vultrmachine.CreationTimestamp, server.DateCreated)
+		machineScope.SetReady()
+		return reconcile.Result{RequeueAfter: reconciler.DefaultedResyncPeriod(r.ResyncPeriod)}, nil
+	case infrav1.SubscriptionStatusClosed:
+		err := errors.Errorf("Bare metal server %s subscription has been closed", server.ID)
+		machineScope.SetNotReady()
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
-		machineScope.SetFailureMessage(errors.Errorf("Instance status %q is unexpected", instance.Status))
+		machineScope.SetFailureMessage(err)
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceProvisionedCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "InstanceClosed", err.Error())
 		return reconcile.Result{}, nil
+	default:
+		machineScope.Info("Machine bare metal server is not active", logging.InstanceIDKey, server.ID, "status", server.Status)
+		machineScope.SetNotReady()
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceNotActiveReason, clusterv1.ConditionSeverityWarning, "Bare metal server status is %q", server.Status)
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "InstanceNotActive", "Bare metal server %s has status %q", server.ID, server.Status)
+		return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, nil
+	}
+}
+
+// reconcileTags updates the tags the provider owns on the instance or bare
+// metal server of a machine when they differ from the spec, e.g. because
+// spec.additionalTags changed or the tags were edited in the Vultr console.
+// Tags added to the server outside of Cluster API are kept.
+func (r *VultrMachineReconciler) reconcileTags(machineScope *scope.MachineScope, instancesvc *services.Service, id string, current []string, update func(id string, tags infrav1.Tags) error) error {
+	tags := instancesvc.MergeMachineTags(machineScope, current)
+	if tags.Equal(current) {
+		machineScope.SetAppliedAdditionalTags(machineScope.AdditionalTags())
+		return nil
+	}
+	if err := update(id, tags); err != nil {
+		r.Recorder.Eventf(machineScope.VultrMachine, corev1.EventTypeWarning, "UpdateTagsFailed", "Failed to update tags of %s: %v", id, err)
+		return err
+	}
+	machineScope.SetAppliedAdditionalTags(machineScope.AdditionalTags())
+	machineScope.Info("Updated tags", logging.InstanceIDKey, id, "tags", tags)
+	r.Recorder.Eventf(machineScope.VultrMachine, corev1.EventTypeNormal, "TagsUpdated", "Updated tags of %s", id)
+	return nil
+}
+
+// reconcileDataVolumes makes sure a block storage exists for every data
+// volume of the spec and records them in the status. Volumes are created
+// before the instance, so that its bootstrap data can mount them.
+func (r *VultrMachineReconciler) reconcileDataVolumes(machineScope *scope.MachineScope, instancesvc *services.Service, instance *govultr.Instance) error {
+	vultrmachine := machineScope.VultrMachine
+	if len(vultrmachine.Spec.DataVolumes) == 0 && len(vultrmachine.Status.DataVolumes) == 0 {
+		conditions.Delete(vultrmachine, infrav1.DataVolumesReadyCondition)
+		return nil
+	}
+
+	missing := false
+	for _, volume := range vultrmachine.Spec.DataVolumes {
+		idx := slices.IndexFunc(vultrmachine.Status.DataVolumes, func(s infrav1.DataVolumeStatus) bool { return s.Label == volume.Label })
+
+		var block *govultr.BlockStorage
+		var err error
+		if idx >= 0 {
+			block, err = instancesvc.GetBlockStorage(vultrmachine.Status.DataVolumes[idx].ID)
+		} else {
+			// The volume may have been created by a reconcile which failed to
+			// record it.
+			block, err = instancesvc.FindBlockStorage(vultrmachine.Spec.Region, services.DataVolumeLabel(machineScope.Name(), volume.Label))
+		}
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			if instance != nil && idx >= 0 {
+				// Recreating the volume would silently lose its data.
+				err := errors.Errorf("Block storage %s of data volume %s was deleted outside of Cluster API", vultrmachine.Status.DataVolumes[idx].ID, volume.Label)
+				conditions.MarkFalse(vultrmachine, infrav1.DataVolumesReadyCondition, infrav1.DataVolumeCreateFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+				r.Recorder.Event(vultrmachine, corev1.EventTypeWarning, "DataVolumeMissing", err.Error())
+				missing = true
+				continue
+			}
+			block, err = instancesvc.CreateBlockStorage(machineScope.Name(), vultrmachine.Spec.Region, volume)
+			if err != nil {
+				severity := clusterv1.ConditionSeverityWarning
+				if services.IsTerminalError(err) {
+					severity = clusterv1.ConditionSeverityError
+				}
+				conditions.MarkFalse(vultrmachine, infrav1.DataVolumesReadyCondition, infrav1.DataVolumeCreateFailedReason, severity, "%s", err.Error())
+				r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "DataVolumeCreateFailed", "Failed to create data volume %s: %v", volume.Label, err)
+				return err
+			}
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "DataVolumeCreated", "Created block storage %s for data volume %s", block.ID, volume.Label)
+		}
+
+		status := infrav1.DataVolumeStatus{
+			Label:    volume.Label,
+			ID:       block.ID,
+			MountID:  block.MountID,
+			Attached: instance != nil && block.AttachedToInstance == instance.ID,
+		}
+		if idx >= 0 {
+			vultrmachine.Status.DataVolumes[idx] = status
+		} else {
+			vultrmachine.Status.DataVolumes = append(vultrmachine.Status.DataVolumes, status)
+		}
+	}
+
+	switch {
+	case missing:
+	case slices.ContainsFunc(vultrmachine.Status.DataVolumes, func(s infrav1.DataVolumeStatus) bool { return !s.Attached }):
+		conditions.MarkFalse(vultrmachine, infrav1.DataVolumesReadyCondition, infrav1.DataVolumeAttachingReason, clusterv1.ConditionSeverityInfo, "")
+	default:
+		conditions.MarkTrue(vultrmachine, infrav1.DataVolumesReadyCondition)
 	}
+	return nil
+}
+
+// attachDataVolumes attaches the data volumes to the active instance. It
+// reports whether the reconcile is done because a volume is not attached.
+func (r *VultrMachineReconciler) attachDataVolumes(machineScope *scope.MachineScope, instancesvc *services.Service, instance *govultr.Instance) (reconcile.Result, bool, error) {
+	vultrmachine := machineScope.VultrMachine
+	if len(vultrmachine.Status.DataVolumes) == 0 {
+		return reconcile.Result{}, false, nil
+	}
+	if conditions.GetReason(vultrmachine, infrav1.DataVolumesReadyCondition) == infrav1.DataVolumeCreateFailedReason {
+		machineScope.SetNotReady()
+		return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, true, nil
+	}
+
+	for i := range vultrmachine.Status.DataVolumes {
+		volume := &vultrmachine.Status.DataVolumes[i]
+		if volume.Attached {
+			continue
+		}
+		if err := instancesvc.AttachBlockStorage(volume.ID, instance.ID); err != nil {
+			machineScope.SetNotReady()
+			conditions.MarkFalse(vultrmachine, infrav1.DataVolumesReadyCondition, infrav1.DataVolumeAttachFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "DataVolumeAttachFailed", "Failed to attach data volume %s to instance %s: %v", volume.Label, instance.ID, err)
+			if services.IsTerminalError(err) {
+				return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, true, nil
+			}
+			return reconcile.Result{}, true, err
+		}
+		volume.Attached = true
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "DataVolumeAttached", "Attached data volume %s to instance %s", volume.Label, instance.ID)
+	}
+	conditions.MarkTrue(vultrmachine, infrav1.DataVolumesReadyCondition)
+	return reconcile.Result{}, false, nil
+}
+
+// deleteDataVolumes detaches the data volumes of a deleted machine and
+// deletes those whose deletion policy says so.
+func (r *VultrMachineReconciler) deleteDataVolumes(machineScope *scope.MachineScope, instancesvc *services.Service) error {
+	vultrmachine := machineScope.VultrMachine
+
+	// The status is lost when the machine is moved by clusterctl, volumes of
+	// the spec it does not list are looked up by their label.
+	volumes := vultrmachine.Status.DataVolumes
+	for _, v := range vultrmachine.Spec.DataVolumes {
+		if slices.ContainsFunc(volumes, func(s infrav1.DataVolumeStatus) bool { return s.Label == v.Label }) {
+			continue
+		}
+		block, err := instancesvc.FindBlockStorage(vultrmachine.Spec.Region, services.DataVolumeLabel(machineScope.Name(), v.Label))
+		if err != nil {
+			return err
+		}
+		if block != nil {
+			volumes = append(volumes, infrav1.DataVolumeStatus{Label: v.Label, ID: block.ID, MountID: block.MountID})
+		}
+	}
+
+	var remaining []infrav1.DataVolumeStatus
+	for _, volume := range volumes {
+		policy := infrav1.VolumeDeletionPolicyDelete
+		for _, v := range vultrmachine.Spec.DataVolumes {
+			if v.Label == volume.Label && v.DeletionPolicy != "" {
+				policy = v.DeletionPolicy
+			}
+		}
+
+		block, err := instancesvc.GetBlockStorage(volume.ID)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		if block.AttachedToInstance != "" {
+			if err := instancesvc.DetachBlockStorage(volume.ID); err != nil {
+				return err
+			}
+		}
+		if policy == infrav1.VolumeDeletionPolicyDelete {
+			if err := instancesvc.DeleteBlockStorage(volume.ID); err != nil {
+				return err
+			}
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "DataVolumeDeleted", "Deleted block storage %s of data volume %s", volume.ID, volume.Label)
+			continue
+		}
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "DataVolumeRetained", "Retained block storage %s of data volume %s", volume.ID, volume.Label)
+		volume.Attached = false
+		remaining = append(remaining, volume)
+	}
+	vultrmachine.Status.DataVolumes = remaining
+	return nil
+}
+
+// reconcileStoppedInstance marks the machine of a stopped instance not ready
+// and starts the instance again if its start policy says so.
+func (r *VultrMachineReconciler) reconcileStoppedInstance(machineScope *scope.MachineScope, instancesvc *services.Service, instance *govultr.Instance) (reconcile.Result, error) {
+	vultrmachine := machineScope.VultrMachine
+	machineScope.SetNotReady()
+
+	// Instances halted through the action annotation stay stopped.
+	halted := vultrmachine.Status.LastAction != nil &&
+		vultrmachine.Status.LastAction.Action == infrav1.MachineActionHalt &&
+		vultrmachine.Status.LastAction.Result == infrav1.MachineActionSucceeded
+
+	if vultrmachine.Spec.StartPolicy != infrav1.StartPolicyAlways || halted {
+		machineScope.Info("Machine instance is stopped", logging.InstanceIDKey, instance.ID)
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityWarning, "Instance is stopped")
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "InstanceStopped", "Instance %s is stopped", instance.ID)
+		return reconcile.Result{RequeueAfter: r.Intervals.RetryAfter()}, nil
+	}
+
+	machineScope.Info("Starting stopped machine instance", logging.InstanceIDKey, instance.ID)
+	if err := instancesvc.StartInstance(instance.ID); err != nil {
+		conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "InstanceStartFailed", "Failed to start instance %s: %v", instance.ID, err)
+		return reconcile.Result{}, err
+	}
+	conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, infrav1.InstanceStartingReason, clusterv1.ConditionSeverityInfo, "")
+	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceStarted", "Started stopped instance %s", instance.ID)
+	return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, nil
+}
+
+// reconcilePlan upgrades the instance in place when the plan of the spec
+// changed and in-place plan upgrades are enabled. It reports whether the
+// reconcile is done, e.g. while an upgrade is in progress.
+func (r *VultrMachineReconciler) reconcilePlan(machineScope *scope.MachineScope, instancesvc *services.Service, instance *govultr.Instance) (reconcile.Result, bool, error) {
+	vultrmachine := machineScope.VultrMachine
+	plan := vultrmachine.Spec.PlanID
+
+	if upgrade := vultrmachine.Status.PlanUpgrade; upgrade != nil {
+		if instance.Plan != upgrade.ToPlan ||
+			infrav1.SubscriptionStatus(instance.Status) != infrav1.SubscriptionStatusActive ||
+			infrav1.ServerState(instance.ServerStatus) == infrav1.ServerStateLocked {
+			machineScope.Info("Machine instance plan upgrade in progress", logging.InstanceIDKey, instance.ID, "plan", upgrade.ToPlan)
+			machineScope.SetNotReady()
+			conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradingReason, clusterv1.ConditionSeverityInfo, "Upgrading from plan %s to %s", upgrade.FromPlan, upgrade.ToPlan)
+			return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, true, nil
+		}
+		vultrmachine.Status.PlanUpgrade = nil
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "PlanUpgraded", "Upgraded instance %s from plan %s to %s", instance.ID, upgrade.FromPlan, upgrade.ToPlan)
+	}
+
+	if plan == "" || instance.Plan == plan {
+		if conditions.Has(vultrmachine, infrav1.PlanUpToDateCondition) {
+			conditions.MarkTrue(vultrmachine, infrav1.PlanUpToDateCondition)
+		}
+		return reconcile.Result{}, false, nil
+	}
+	// Instances are only upgraded once they are up and running.
+	if infrav1.SubscriptionStatus(instance.Status) != infrav1.SubscriptionStatusActive {
+		return reconcile.Result{}, false, nil
+	}
+
+	if !vultrmachine.Spec.InPlacePlanUpgrade {
+		conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradeDisabledReason, clusterv1.ConditionSeverityWarning, "Instance has plan %s, in-place plan upgrades are disabled", instance.Plan)
+		return reconcile.Result{}, false, nil
+	}
+
+	upgrades, err := instancesvc.GetPlanUpgrades(instance.ID)
+	if err != nil {
+		conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradeFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return reconcile.Result{}, true, err
+	}
+	if !slices.Contains(upgrades, plan) {
+		if conditions.GetReason(vultrmachine, infrav1.PlanUpToDateCondition) != infrav1.PlanUpgradeRejectedReason {
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "PlanUpgradeRejected", "Plan %s is not an upgrade of plan %s of instance %s", plan, instance.Plan, instance.ID)
+		}
+		conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradeRejectedReason, clusterv1.ConditionSeverityError, "Plan %s is not an upgrade of plan %s, plans cannot be downgraded", plan, instance.Plan)
+		return reconcile.Result{}, false, nil
+	}
+
+	if err := instancesvc.UpgradeInstancePlan(instance.ID, plan); err != nil {
+		conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradeFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "PlanUpgradeFailed", "Failed to upgrade instance %s to plan %s: %v", instance.ID, plan, err)
+		if services.IsTerminalError(err) {
+			return reconcile.Result{}, false, nil
+		}
+		return reconcile.Result{}, true, err
+	}
+	vultrmachine.Status.PlanUpgrade = &infrav1.VultrMachinePlanUpgradeStatus{
+		FromPlan:  instance.Plan,
+		ToPlan:    plan,
+		StartTime: metav1.Now(),
+	}
+	machineScope.SetNotReady()
+	conditions.MarkFalse(vultrmachine, infrav1.PlanUpToDateCondition, infrav1.PlanUpgradingReason, clusterv1.ConditionSeverityInfo, "Upgrading from plan %s to %s", instance.Plan, plan)
+	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "PlanUpgrading", "Upgrading instance %s from plan %s to %s", instance.ID, instance.Plan, plan)
+	return reconcile.Result{RequeueAfter: r.Intervals.PollAfter()}, true, nil
+}
+
+// reconcileAction performs the action requested through the action annotation
+// and records it in the status. The annotations are removed so that the
+// action runs once. It reports whether an action was performed.
+func (r *VultrMachineReconciler) reconcileAction(machineScope *scope.MachineScope, instancesvc *services.Service, instance *govultr.Instance) bool {
+	vultrmachine := machineScope.VultrMachine
+	action, ok := vultrmachine.Annotations[infrav1.ActionAnnotation]
+	if !ok {
+		return false
+	}
+	force := vultrmachine.Annotations[infrav1.ForceActionAnnotation] == "true"
+	delete(vultrmachine.Annotations, infrav1.ActionAnnotation)
+	delete(vultrmachine.Annotations, infrav1.ForceActionAnnotation)
+
+	status := &infrav1.VultrMachineActionStatus{
+		Action: infrav1.MachineAction(action),
+		Time:   metav1.Now(),
+		Result: infrav1.MachineActionSucceeded,
+	}
+	vultrmachine.Status.LastAction = status
+
+	switch status.Action {
+	case infrav1.MachineActionReboot, infrav1.MachineActionHalt, infrav1.MachineActionStart, infrav1.MachineActionReinstall:
+		if machineScope.IsControlPlane() && !force {
+			status.Result = infrav1.MachineActionRejected
+			status.Message = fmt.Sprintf("actions on control plane machines require the %s annotation", infrav1.ForceActionAnnotation)
+			r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "ActionRejected", "Rejected %s of instance %s: %s", action, instance.ID, status.Message)
+			return false
+		}
+	default:
+		status.Result = infrav1.MachineActionRejected
+		status.Message = fmt.Sprintf("unknown action %q", action)
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "ActionRejected", "Rejected action on instance %s: %s", instance.ID, status.Message)
+		return false
+	}
+
+	machineScope.Info("Performing requested action", logging.InstanceIDKey, instance.ID, "action", action)
+	var err error
+	switch status.Action {
+	case infrav1.MachineActionReboot:
+		err = instancesvc.RebootInstance(instance.ID)
+	case infrav1.MachineActionHalt:
+		err = instancesvc.HaltInstance(instance.ID)
+	case infrav1.MachineActionStart:
+		err = instancesvc.StartInstance(instance.ID)
+	case infrav1.MachineActionReinstall:
+		err = instancesvc.ReinstallInstance(machineScope, instance.ID)
+	}
+	if err != nil {
+		status.Result = infrav1.MachineActionFailed
+		status.Message = err.Error()
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "ActionFailed", "Failed to %s instance %s: %v", action, instance.ID, err)
+		return false
+	}
+
+	if status.Action == infrav1.MachineActionHalt || status.Action == infrav1.MachineActionReinstall {
+		machineScope.SetNotReady()
+	}
+	r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "ActionSucceeded", "Performed %s of instance %s", action, instance.ID)
+	return true
 }
 
 func (r *VultrMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) { //nolint: unparam
 	machineScope.Info("Reconciling delete VultrMachine")
 	vultrmachine := machineScope.VultrMachine
+	conditions.MarkFalse(vultrmachine, infrav1.InstanceRunningCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 
 	vultrcomputesvc := services.NewService(ctx, clusterScope)
+	if machineScope.IsBareMetal() {
+		return r.reconcileDeleteBareMetal(machineScope, vultrcomputesvc)
+	}
+
 	vultrInstance, err := vultrcomputesvc.GetInstance(machineScope.GetInstanceID())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if err := r.deleteDataVolumes(machineScope, vultrcomputesvc); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if vultrInstance != nil {
 		if err := vultrcomputesvc.DeleteInstance(machineScope.GetInstanceID()); err != nil {
 			return reconcile.Result{}, err
@@ -259,7 +945,29 @@ func (r *VultrMachineReconciler) reconcileDelete(ctx context.Context, machineSco
 	controllerutil.RemoveFinalizer(vultrmachine, infrav1.MachineFinalizer)
 	return reconcile.Result{}, nil
 }
-func (r *VultrMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, _ controller.Options) error {
+
+// reconcileDeleteBareMetal deletes the bare metal server of a machine.
+func (r *VultrMachineReconciler) reconcileDeleteBareMetal(machineScope *scope.MachineScope, baremetalsvc *services.Service) (reconcile.Result, error) {
+	vultrmachine := machineScope.VultrMachine
+
+	server, err := baremetalsvc.GetBareMetal(machineScope.GetInstanceID())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if server != nil {
+		if err := baremetalsvc.DeleteBareMetal(server.ID); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeNormal, "InstanceDeleted", "Deleted bare metal server %s", server.ID)
+	} else {
+		r.Recorder.Eventf(vultrmachine, corev1.EventTypeWarning, "NoInstanceFound", "Skip deleting")
+	}
+
+	controllerutil.RemoveFinalizer(vultrmachine, infrav1.MachineFinalizer)
+	return reconcile.Result{}, nil
+}
+
+func (r *VultrMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	clusterToObjectFunc, err := util.ClusterToTypedObjectsMapper(r.Client, &infrav1.VultrMachineList{}, mgr.GetScheme())
 	if err != nil {
 		return errors.Wrapf(err, "failed to create mapper for Cluster to VultrMachines")
@@ -267,7 +975,7 @@ func (r *VultrMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.VultrMachine{}).
-		WithEventFilter(predicates.ResourceNotPaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx))).
+		WithOptions(options).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(util.MachineToInfrastructureMapFunc(infrav1.GroupVersion.WithKind("VultrMachine"))),
@@ -281,6 +989,7 @@ func (r *VultrMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 			handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
 			builder.WithPredicates(predicates.ClusterPausedTransitionsOrInfrastructureReady(mgr.GetScheme(), ctrl.LoggerFrom(ctx))),
 		).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 }
 
@@ -18,66 +18,37 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrastructurev1beta1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
 )
 
 var _ = Describe("VultrMachine Controller", func() {
-	Context("When reconciling a resource", func() {
-		const resourceName = "test-resource"
-
-		ctx := context.Background()
-
-		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
-		}
-		vultrmachine := &infrastructurev1beta1.VultrMachine{}
-
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind VultrMachine")
-			err := k8sClient.Get(ctx, typeNamespacedName, vultrmachine)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &infrastructurev1beta1.VultrMachine{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+	Context("When the VultrMachine has no owner Machine", func() {
+		It("should leave it alone", func() {
+			ctx := context.Background()
+
+			By("creating a VultrMachine without an owner Machine")
+			vultrmachine := &infrastructurev1beta1.VultrMachine{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "orphan-", Namespace: "default"},
+				Spec:       infrastructurev1beta1.VultrMachineSpec{Region: "ewr", PlanID: "vc2-2c-4gb"},
 			}
-		})
-
-		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &infrastructurev1beta1.VultrMachine{}
-			err := k8sClient.Get(ctx, typeNamespacedName, resource)
-			Expect(err).NotTo(HaveOccurred())
-
-			By("Cleanup the specific resource instance VultrMachine")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
-		})
-		It("should successfully reconcile the resource", func() {
-			By("Reconciling the created resource")
-			controllerReconciler := &VultrMachineReconciler{
-				Client: k8sClient,
-			}
-
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+			Expect(k8sClient.Create(ctx, vultrmachine)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, vultrmachine))).To(Succeed())
 			})
-			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			Consistently(func(g Gomega) {
+				got := &infrastructurev1beta1.VultrMachine{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(vultrmachine), got)).To(Succeed())
+				g.Expect(got.Finalizers).To(BeEmpty())
+				g.Expect(got.Spec.ProviderID).To(BeNil())
+			}, 2*time.Second, lifecycleInterval).Should(Succeed())
 		})
 	})
 })
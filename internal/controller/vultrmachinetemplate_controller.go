@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/vultr/cluster-api-provider-vultr/api/v1beta1"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/scope"
+	"github.com/vultr/cluster-api-provider-vultr/cloud/services"
+	"github.com/vultr/cluster-api-provider-vultr/util/reconciler"
+	"github.com/vultr/cluster-api-provider-vultr/util/tracing"
+)
+
+// VultrMachineTemplateReconciler reconciles a VultrMachineTemplate object
+type VultrMachineTemplateReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+	// ClientFactory creates the Vultr API clients. The clients are created
+	// from the environment when it is nil.
+	ClientFactory scope.ClientFactory
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vultrmachinetemplates,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vultrmachinetemplates/status,verbs=get;update;patch
+
+// Reconcile fills in the capacity of VultrMachineTemplates from their plan,
+// so that the cluster-autoscaler can scale MachineDeployments from zero.
+func (r *VultrMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	ctx, span := tracing.StartSpan(ctx, "VultrMachineTemplateReconciler.Reconcile",
+		attribute.String("namespace", req.Namespace),
+		attribute.String("name", req.Name),
+	)
+	defer func() { tracing.EndSpan(span, reterr) }()
+
+	log := ctrl.LoggerFrom(ctx)
+
+	vultrMachineTemplate := &infrav1.VultrMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, vultrMachineTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer func() { recordInvalidCredentials(r.Recorder, vultrMachineTemplate, reterr) }()
+	if !vultrMachineTemplate.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Templates are owned by the Cluster once a MachineDeployment or the
+	// topology controller uses them.
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, vultrMachineTemplate.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if annotations.HasPaused(vultrMachineTemplate) || (cluster != nil && cluster.Spec.Paused) {
+		log.Info("VultrMachineTemplate or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{}, nil
+	}
+
+	status := vultrMachineTemplate.Status
+	if status.Capacity != nil && status.ObservedGeneration == vultrMachineTemplate.Generation {
+		return reconcile.Result{}, nil
+	}
+
+	clients, err := scope.NewClients(r.ClientFactory)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	templateScope, err := scope.NewMachineTemplateScope(scope.MachineTemplateScopeParams{
+		VultrAPIClients:      clients,
+		Client:               r.Client,
+		Logger:               log,
+		VultrMachineTemplate: vultrMachineTemplate,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create machine template scope: %v", err)
+	}
+	defer func() {
+		if err := templateScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	planID := vultrMachineTemplate.Spec.Template.Spec.PlanID
+	capacity, gpuType, err := services.NewTemplateService(ctx, templateScope).GetPlanCapacity()
+	if err != nil {
+		r.Recorder.Eventf(vultrMachineTemplate, corev1.EventTypeWarning, "PlanLookupFailed", "Failed to look up plan %s: %v", planID, err)
+		return reconcile.Result{}, err
+	}
+	switch {
+	case capacity == nil:
+		r.Recorder.Eventf(vultrMachineTemplate, corev1.EventTypeWarning, "PlanNotFound", "Plan %s does not exist, capacity is unknown", planID)
+	case gpuType != "":
+		r.Recorder.Eventf(vultrMachineTemplate, corev1.EventTypeWarning, "GPUCapacityUnknown",
+			"Plan %s has %s GPUs whose count is not part of the capacity, scaling from zero needs the capacity.cluster-autoscaler.kubernetes.io/gpu-count annotation", planID, gpuType)
+		templateScope.Info("Updated capacity from plan", "plan", planID)
+	default:
+		templateScope.Info("Updated capacity from plan", "plan", planID)
+	}
+	vultrMachineTemplate.Status.Capacity = capacity
+	vultrMachineTemplate.Status.ObservedGeneration = vultrMachineTemplate.Generation
+	return reconcile.Result{}, nil
+}
+
+func (r *VultrMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	clusterToObjectFunc, err := util.ClusterToTypedObjectsMapper(r.Client, &infrav1.VultrMachineTemplateList{}, mgr.GetScheme())
+	if err != nil {
+		return errors.Wrapf(err, "failed to create mapper for Cluster to VultrMachineTemplates")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.VultrMachineTemplate{}).
+		WithOptions(options).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
+			builder.WithPredicates(predicates.ClusterUnpaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx))),
+		).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging defines the log keys shared by the provider and a logr
+// sink which keeps secrets out of the logs.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+)
+
+// Keys used for the key/value pairs of every log line about a Vultr resource.
+const (
+	ClusterKey        = "cluster"
+	MachineKey        = "machine"
+	InstanceIDKey     = "instance-id"
+	LoadBalancerIDKey = "loadbalancer-id"
+	SSHKeyIDKey       = "sshkey-id"
+	BlockStorageIDKey = "blockstorage-id"
+	TemplateKey       = "machine-template"
+)
+
+// Redacted replaces secret values in log lines.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are normalized log keys whose values are always redacted.
+var sensitiveKeys = map[string]struct{}{
+	"apikey":          {},
+	"token":           {},
+	"accesstoken":     {},
+	"password":        {},
+	"defaultpassword": {},
+	"userdata":        {},
+	"bootstrapdata":   {},
+}
+
+var (
+	secretsMu sync.RWMutex
+	secrets   = map[string]struct{}{}
+)
+
+// AddSecret registers s so that it is redacted wherever it appears in a log
+// line, e.g. inside an error message. Registering a secret again is a no-op.
+func AddSecret(s string) {
+	if s == "" {
+		return
+	}
+	secretsMu.RLock()
+	_, ok := secrets[s]
+	secretsMu.RUnlock()
+	if ok {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets[s] = struct{}{}
+}
+
+// RemoveSecret stops redacting s once it is no longer used, e.g. after an API
+// key was rotated.
+func RemoveSecret(s string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	delete(secrets, s)
+}
+
+// Redact replaces every registered secret in s.
+func Redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	return s
+}
+
+// NewRedactingLogger returns a logger writing to the sink of l which redacts
+// the values of sensitive keys and every secret registered with AddSecret.
+func NewRedactingLogger(l logr.Logger) logr.Logger {
+	sink := l.GetSink()
+	if sink == nil {
+		return l
+	}
+	// Account for the frame added by redactingSink so callers are reported
+	// correctly. The underlying sink has already been initialized by l.
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+	return logr.New(&redactingSink{sink: sink})
+}
+
+type redactingSink struct {
+	sink logr.LogSink
+}
+
+var _ logr.CallDepthLogSink = &redactingSink{}
+
+// Init implements logr.LogSink.
+func (r *redactingSink) Init(logr.RuntimeInfo) {}
+
+// Enabled implements logr.LogSink.
+func (r *redactingSink) Enabled(level int) bool {
+	return r.sink.Enabled(level)
+}
+
+// Info implements logr.LogSink.
+func (r *redactingSink) Info(level int, msg string, keysAndValues ...any) {
+	r.sink.Info(level, Redact(msg), redactKeysAndValues(keysAndValues)...)
+}
+
+// Error implements logr.LogSink.
+func (r *redactingSink) Error(err error, msg string, keysAndValues ...any) {
+	r.sink.Error(redactError(err), Redact(msg), redactKeysAndValues(keysAndValues)...)
+}
+
+// WithValues implements logr.LogSink.
+func (r *redactingSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &redactingSink{sink: r.sink.WithValues(redactKeysAndValues(keysAndValues)...)}
+}
+
+// WithName implements logr.LogSink.
+func (r *redactingSink) WithName(name string) logr.LogSink {
+	return &redactingSink{sink: r.sink.WithName(name)}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink.
+func (r *redactingSink) WithCallDepth(depth int) logr.LogSink {
+	if cd, ok := r.sink.(logr.CallDepthLogSink); ok {
+		return &redactingSink{sink: cd.WithCallDepth(depth)}
+	}
+	return r
+}
+
+func redactKeysAndValues(keysAndValues []any) []any {
+	out := make([]any, len(keysAndValues))
+	for i := 0; i < len(keysAndValues); i += 2 {
+		out[i] = keysAndValues[i]
+		if i+1 == len(keysAndValues) {
+			break
+		}
+		key, _ := keysAndValues[i].(string)
+		out[i+1] = redactValue(key, keysAndValues[i+1])
+	}
+	return out
+}
+
+func redactValue(key string, value any) any {
+	if isSensitiveKey(key) {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case []byte:
+		return Redact(string(v))
+	case error:
+		return redactError(v)
+	case fmt.Stringer:
+		if s := v.String(); Redact(s) != s {
+			return Redact(s)
+		}
+	}
+	return value
+}
+
+func redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := err.Error(); Redact(msg) != msg {
+		return errors.New(Redact(msg))
+	}
+	return err
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "", ".", "").Replace(strings.ToLower(key))
+	_, ok := sensitiveKeys[normalized]
+	return ok
+}
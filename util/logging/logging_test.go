@@ -0,0 +1,66 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedactingLogger(t *testing.T) {
+	g := NewWithT(t)
+
+	const apiKey = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+	AddSecret(apiKey)
+
+	var lines []string
+	log := NewRedactingLogger(funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{}))
+
+	log.WithValues("user_data", "#cloud-config").Info("creating instance", InstanceIDKey, "abc")
+	log.Info("calling API", "bootstrapData", []byte("secret"), "header", "Bearer "+apiKey)
+	log.Error(errors.New("unauthorized key "+apiKey), "request failed", "defaultPassword", "hunter2")
+
+	g.Expect(lines).To(HaveLen(3))
+	g.Expect(lines[0]).To(ContainSubstring(`"instance-id"="abc"`))
+	for _, line := range lines {
+		g.Expect(line).NotTo(ContainSubstring(apiKey))
+		g.Expect(line).NotTo(ContainSubstring("#cloud-config"))
+		g.Expect(line).NotTo(ContainSubstring("secret"))
+		g.Expect(line).NotTo(ContainSubstring("hunter2"))
+		g.Expect(line).To(ContainSubstring(Redacted))
+	}
+}
+
+func TestRemoveSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	const apiKey = "rotated-api-key"
+	AddSecret(apiKey)
+	AddSecret(apiKey)
+	g.Expect(Redact("key " + apiKey)).To(Equal("key " + Redacted))
+
+	RemoveSecret(apiKey)
+	g.Expect(Redact("key " + apiKey)).To(Equal("key " + apiKey))
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	g.Expect(secrets).NotTo(HaveKey(apiKey))
+}
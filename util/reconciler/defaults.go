@@ -24,10 +24,24 @@ import (
 const (
 	// DefaultLoopTimeout is the default timeout for a reconcile loop (
 	DefaultLoopTimeout = 90 * time.Minute
+	// DefaultResyncPeriod is the default period after which ready machines are
+	// reconciled again to detect changes made outside of Cluster API.
+	DefaultResyncPeriod = 5 * time.Minute
 	// DefaultMappingTimeout is the default timeout for a controller request mapping func.
 	DefaultMappingTimeout = 60 * time.Second
-	// DefaultRetryTime is the default time to retry when certain conditions are not met.
-	//DefaultRetryTime = 1 * time.Minute
+	// DefaultPollInterval is the default delay after which resources which are
+	// being provisioned or changed are checked again.
+	DefaultPollInterval = 10 * time.Second
+	// DefaultRetryInterval is the default delay after which conditions which
+	// need a change outside of the provider, e.g. a stopped instance, are
+	// checked again.
+	DefaultRetryInterval = 1 * time.Minute
+	// DefaultBackoffBaseDelay is the default delay before a failed reconcile
+	// is retried for the first time.
+	DefaultBackoffBaseDelay = 5 * time.Millisecond
+	// DefaultBackoffMaxDelay is the default cap of the delay between retries
+	// of failed reconciles.
+	DefaultBackoffMaxDelay = 1000 * time.Second
 )
 
 // DefaultedLoopTimeout will default the timeout if it is zero valued.
@@ -38,3 +52,12 @@ func DefaultedLoopTimeout(timeout time.Duration) time.Duration {
 
 	return timeout
 }
+
+// DefaultedResyncPeriod will default the resync period if it is zero valued.
+func DefaultedResyncPeriod(period time.Duration) time.Duration {
+	if period <= 0 {
+		return DefaultResyncPeriod
+	}
+
+	return period
+}
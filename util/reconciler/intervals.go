@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Intervals are the delays after which the reconcilers check Vultr resources
+// again. Zero values are defaulted.
+type Intervals struct {
+	// Poll is the delay for resources which are being provisioned or
+	// changed, e.g. a pending instance.
+	Poll time.Duration
+	// Retry is the delay for conditions which need a change outside of the
+	// provider, e.g. a snapshot which is not ready or a stopped instance.
+	Retry time.Duration
+	// Jitter randomly extends the delays by up to this fraction of them, so
+	// that objects created together are not all checked at the same time.
+	Jitter float64
+}
+
+// PollAfter returns the delay after which a resource which is being
+// provisioned or changed is checked again.
+func (i Intervals) PollAfter() time.Duration {
+	return i.jitter(defaulted(i.Poll, DefaultPollInterval))
+}
+
+// RetryAfter returns the delay after which a condition which needs a change
+// outside of the provider is checked again.
+func (i Intervals) RetryAfter() time.Duration {
+	return i.jitter(defaulted(i.Retry, DefaultRetryInterval))
+}
+
+func (i Intervals) jitter(d time.Duration) time.Duration {
+	if i.Jitter <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Float64()*i.Jitter*float64(d))
+}
+
+func defaulted(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// NewRateLimiter returns the rate limiter of the controller work queues. Failed
+// reconciles are retried with an exponential backoff from baseDelay up to
+// maxDelay, zero values are defaulted. Like the controller-runtime default,
+// the queue is limited to 10 requests per second overall, with bursts of 100.
+func NewRateLimiter(baseDelay, maxDelay time.Duration) workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
+			defaulted(baseDelay, DefaultBackoffBaseDelay),
+			defaulted(maxDelay, DefaultBackoffMaxDelay),
+		),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestIntervals(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Intervals{}.PollAfter()).To(Equal(DefaultPollInterval))
+	g.Expect(Intervals{}.RetryAfter()).To(Equal(DefaultRetryInterval))
+
+	i := Intervals{Poll: 2 * time.Second, Retry: 30 * time.Second}
+	g.Expect(i.PollAfter()).To(Equal(2 * time.Second))
+	g.Expect(i.RetryAfter()).To(Equal(30 * time.Second))
+
+	i.Jitter = 0.5
+	for range 100 {
+		g.Expect(i.PollAfter()).To(BeNumerically("~", 2500*time.Millisecond, 500*time.Millisecond))
+		g.Expect(i.RetryAfter()).To(BeNumerically("~", 37500*time.Millisecond, 7500*time.Millisecond))
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}}
+	limiter := NewRateLimiter(time.Second, 4*time.Second)
+	var delays []time.Duration
+	for range 4 {
+		delays = append(delays, limiter.When(item))
+	}
+	g.Expect(delays).To(Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}))
+
+	limiter.Forget(item)
+	g.Expect(limiter.When(item)).To(Equal(time.Second))
+	g.Expect(NewRateLimiter(0, 0).When(item)).To(Equal(DefaultBackoffBaseDelay))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider recording the spans ended during the
+// test in the returned exporter.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func TestSetupDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	shutdown, err := Setup(context.Background(), Options{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(shutdown(context.Background())).To(Succeed())
+}
+
+func TestStartSpan(t *testing.T) {
+	g := NewWithT(t)
+	exporter := recordSpans(t)
+
+	g.Expect(TraceID(context.Background())).To(BeEmpty())
+
+	ctx, parent := StartSpan(context.Background(), "Reconcile")
+	g.Expect(TraceID(ctx)).To(Equal(parent.SpanContext().TraceID().String()))
+
+	_, child := StartSpan(ctx, "Child", attribute.String("instance.id", "abc"))
+	EndSpan(child, nil)
+	_, failed := StartSpan(ctx, "Failed")
+	EndSpan(failed, errors.New("boom"))
+	EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	g.Expect(spans).To(HaveLen(3))
+
+	g.Expect(spans[0].Name).To(Equal("Child"))
+	g.Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+	g.Expect(spans[0].Attributes).To(ContainElement(attribute.String("instance.id", "abc")))
+	g.Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+
+	g.Expect(spans[1].Name).To(Equal("Failed"))
+	g.Expect(spans[1].Status).To(Equal(sdktrace.Status{Code: codes.Error, Description: "boom"}))
+	g.Expect(spans[1].Events).To(ContainElement(HaveField("Name", "exception")))
+
+	g.Expect(spans[2].Name).To(Equal("Reconcile"))
+	g.Expect(spans[2].Parent.IsValid()).To(BeFalse())
+}